@@ -35,21 +35,31 @@ resource "theta_deployment_template" "my_first_tf_managed_template" {
   icon_url     = ""
 }
 
+# Keep the notebooks when the deployment is replaced
+resource "theta_volume" "notebooks" {
+  name       = "notebooks"
+  project_id = data.theta_projects.projects.projects[0].id
+  size_gb    = 10
+}
+
 # Create a deployment using the deployment template created above
 resource "theta_deployment" "notebook_deployment" {
   name                = "notebookdeployment"
   project_id          = data.theta_projects.projects.projects[0].id
-  deployment_image_id = theta_deployment_template.my_first_tf_managed_template.id
-  container_image    = theta_deployment_template.my_first_tf_managed_template.container_images[0]
+  deployment_template_id = theta_deployment_template.my_first_tf_managed_template.id
   min_replicas       = 1
   max_replicas       = 1
   vm_id              = "vm_c1"  # Replace with an actual VM ID if needed
-  annotations        = {
-    tags     = "[\"CodeGen\"]"
-    nickname = ""
-  }
+  tags               = ["CodeGen"]
   auth_username      = "my_user"
   auth_password      = "my_hackathon_winning_password"
+
+  volume_mounts = [
+    {
+      volume_id  = theta_volume.notebooks.id
+      mount_path = "/home/jovyan/work"
+    }
+  ]
 }
 
 output "deployment_url" {
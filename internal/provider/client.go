@@ -3,13 +3,19 @@ package provider
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
+// errNotFound is wrapped by client errors for objects which don't exist (anymore),
+// so resources can tell drift apart from other failures with errors.Is
+var errNotFound = errors.New("not found")
+
 type Client struct {
 	baseURL           string
 	baseControllerURL string
+	baseVideoURL      string
 	authToken         string
 	userID            string
 	orgID             string
@@ -20,6 +26,7 @@ func NewClient(email, password string) *Client {
 	client := &Client{
 		baseURL:           "https://api.thetaedgecloud.com",
 		baseControllerURL: "https://controller.thetaedgecloud.com",
+		baseVideoURL:      "https://api.thetavideoapi.com",
 		httpClient:        &http.Client{},
 	}
 
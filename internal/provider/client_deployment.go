@@ -1,58 +1,141 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	neturl "net/url"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// How often the controller is polled while waiting on a deployment
+const deploymentPollInterval = 10 * time.Second
+
+var errDeploymentNotFound = fmt.Errorf("Deployment %w", errNotFound)
+
 type DeploymentCreateRequest struct {
-	ID                types.String            `tfsdk:"id"`
-	Name              types.String            `tfsdk:"name"`
-	ProjectID         types.String            `tfsdk:"project_id"`
-	DeploymentImageID types.String            `tfsdk:"deployment_image_id"`
-	ContainerImage    types.String            `tfsdk:"container_image"`
-	MinReplicas       types.Int64             `tfsdk:"min_replicas"`
-	MaxReplicas       types.Int64             `tfsdk:"max_replicas"`
-	VMID              types.String            `tfsdk:"vm_id"`
-	Annotations       map[string]types.String `tfsdk:"annotations"`
-	AuthUsername      types.String            `tfsdk:"auth_username"`
-	AuthPassword      types.String            `tfsdk:"auth_password"`
-	URL               types.String            `tfsdk:"deployment_url"`
+	ID                    types.String   `tfsdk:"id"`
+	Name                  types.String   `tfsdk:"name"`
+	ProjectID             types.String   `tfsdk:"project_id"`
+	DeploymentTemplateID  types.String   `tfsdk:"deployment_template_id"`
+	DeploymentImageID     types.String   `tfsdk:"deployment_image_id"`
+	ContainerImage        types.String   `tfsdk:"container_image"`
+	RegistryCredentialID  types.String   `tfsdk:"registry_credential_id"`
+	ContainerPort         types.Int64    `tfsdk:"container_port"`
+	ContainerArgs         types.List     `tfsdk:"container_args"`
+	EnvVars               types.Map      `tfsdk:"env_vars"`
+	SecretEnvVars         types.Map      `tfsdk:"secret_env_vars"`
+	SecretRefs            types.Map      `tfsdk:"secret_refs"`
+	VolumeMounts          types.List     `tfsdk:"volume_mounts"`
+	MinReplicas           types.Int64    `tfsdk:"min_replicas"`
+	MaxReplicas           types.Int64    `tfsdk:"max_replicas"`
+	Autoscaling           types.Object   `tfsdk:"autoscaling"`
+	Enabled               types.Bool     `tfsdk:"enabled"`
+	VMID                  types.String   `tfsdk:"vm_id"`
+	Annotations           types.Map      `tfsdk:"annotations"`
+	Tags                  types.Set      `tfsdk:"tags"`
+	Nickname              types.String   `tfsdk:"nickname"`
+	AuthUsername          types.String   `tfsdk:"auth_username"`
+	AuthPassword          types.String   `tfsdk:"auth_password"`
+	AuthPasswordVersion   types.Int64    `tfsdk:"auth_password_version"`
+	AuthPasswordHash      types.String   `tfsdk:"auth_password_hash"`
+	GeneratedAuthPassword types.String   `tfsdk:"generated_auth_password"`
+	URL                   types.String   `tfsdk:"deployment_url"`
+	Status                types.String   `tfsdk:"status"`
+	RunningReplicas       types.Int64    `tfsdk:"running_replicas"`
+	CreatedAt             types.String   `tfsdk:"created_at"`
+	MachineTypeDetails    types.Map      `tfsdk:"machine_type_details"`
+	Replicas              types.List     `tfsdk:"replicas"`
+	WaitForReady          types.Bool     `tfsdk:"wait_for_ready"`
+	ReplacementStrategy   types.String   `tfsdk:"replacement_strategy"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
 }
 
 type DeploymentCreateRequestNative struct {
-	Name              string            `json:"name"`
-	ProjectID         string            `json:"project_id"`
-	DeploymentImageID string            `json:"deployment_image_id"`
-	ContainerImage    string            `json:"container_image"`
-	MinReplicas       int64             `json:"min_replicas"`
-	MaxReplicas       int64             `json:"max_replicas"`
-	VMID              string            `json:"vm_id"`
-	Annotations       map[string]string `json:"annotations"` // Ensure correct format
-	AuthUsername      string            `json:"auth_username"`
-	AuthPassword      string            `json:"auth_password"`
-	URL               string            `json:"deployment_url"`
+	Name                 string                  `json:"name"`
+	ProjectID            string                  `json:"project_id"`
+	DeploymentImageID    string                  `json:"deployment_image_id"`
+	ContainerImage       string                  `json:"container_image"`
+	RegistryCredentialID string                  `json:"registry_credential_id,omitempty"`
+	ContainerPort        int64                   `json:"container_port,omitempty"`
+	ContainerArgs        []string                `json:"container_args,omitempty"`
+	EnvVars              map[string]string       `json:"env_vars,omitempty"`
+	SecretRefs           map[string]string       `json:"secret_refs,omitempty"`
+	VolumeMounts         []DeploymentVolumeMount `json:"volume_mounts"` // always sent, so removed mounts are detached
+	MinReplicas          int64                   `json:"min_replicas"`
+	MaxReplicas          int64                   `json:"max_replicas"`
+	Autoscaling          *DeploymentAutoscaling  `json:"autoscaling"` // always sent, so a removed policy is cleared
+	Paused               bool                    `json:"paused"`
+	VMID                 string                  `json:"vm_id"`
+	Annotations          map[string]string       `json:"annotations"` // Ensure correct format
+	AuthUsername         string                  `json:"auth_username"`
+	AuthPassword         string                  `json:"auth_password,omitempty"` // omitted to keep the current password on update
+	URL                  string                  `json:"deployment_url"`
 }
 
+// replicasNotReported marks a replica count the controller didn't list, which is taken from state instead
+const replicasNotReported = -1
+
 // Deployment represents the structure of a deployment response.
 type Deployment struct {
-	ID                string            `json:"id"`
-	Name              string            `json:"name"`
-	ProjectID         string            `json:"project_id"`
-	DeploymentImageID string            `json:"deployment_image_id"`
-	ContainerImage    string            `json:"container_image"`
-	MinReplicas       int64             `json:"min_replicas"`
-	MaxReplicas       int64             `json:"max_replicas"`
-	VMID              string            `json:"vm_id"`
-	Annotations       map[string]string `json:"annotations"`
-	AuthUsername      string            `json:"auth_username"`
-	AuthPassword      string            `json:"auth_password"`
-	ContainerPort     int64             `json:"container_port"`
-	URL               string            `json:"deployment_url"`
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name"`
+	ProjectID            string                  `json:"project_id"`
+	DeploymentImageID    string                  `json:"deployment_image_id"`
+	ContainerImage       string                  `json:"container_image"`
+	RegistryCredentialID string                  `json:"registry_credential_id"`
+	MinReplicas          int64                   `json:"min_replicas"`
+	MaxReplicas          int64                   `json:"max_replicas"`
+	Autoscaling          *DeploymentAutoscaling  `json:"autoscaling"`
+	Paused               *bool                   `json:"paused"`
+	VMID                 string                  `json:"vm_id"`
+	Annotations          map[string]string       `json:"annotations"`
+	AuthUsername         string                  `json:"auth_username"`
+	AuthPassword         string                  `json:"auth_password"`
+	ContainerPort        int64                   `json:"container_port"`
+	ContainerArgs        []string                `json:"container_args"`
+	EnvVars              map[string]string       `json:"env_vars"`
+	SecretRefs           map[string]string       `json:"secret_refs"`
+	VolumeMounts         []DeploymentVolumeMount `json:"volume_mounts"`
+	URL                  string                  `json:"deployment_url"`
+	Status               string                  `json:"status"`
+	RunningReplicas      int64                   `json:"running_replicas"`
+	StatusMessage        string                  `json:"status_message"`
+	CreatedAt            string                  `json:"created_at"`
+	MachineTypeDetails   map[string]string       `json:"machine_type_details"`
+	Replicas             []DeploymentReplica     `json:"replicas"`
+}
+
+// DeploymentAutoscaling is the scaling configuration of the controller, scaling between the replica bounds of the deployment
+type DeploymentAutoscaling struct {
+	Metric                        string `json:"metric"`
+	Target                        int64  `json:"target"`
+	ScaleUpCooldownSeconds        int64  `json:"scale_up_cooldown_seconds,omitempty"`
+	ScaleDownCooldownSeconds      int64  `json:"scale_down_cooldown_seconds,omitempty"`
+	ScaleToZeroIdleTimeoutSeconds int64  `json:"scale_to_zero_idle_timeout_seconds,omitempty"`
+}
+
+// DeploymentVolumeMount attaches a volume to the containers of a deployment
+type DeploymentVolumeMount struct {
+	VolumeID  string `json:"volume_id"`
+	MountPath string `json:"mount_path"`
+	ReadOnly  bool   `json:"read_only"`
+}
+
+// DeploymentReplica represents a single pod of a deployment
+type DeploymentReplica struct {
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	Ready        bool   `json:"ready"`
+	RestartCount int64  `json:"restart_count"`
+	StartedAt    string `json:"started_at"`
 }
 
 func (c *Client) CreateDeployment(req DeploymentCreateRequestNative) (*Deployment, error) {
@@ -92,18 +175,26 @@ func (c *Client) CreateDeployment(req DeploymentCreateRequestNative) (*Deploymen
 
 	// Return the deployment with the ID and URL set
 	return &Deployment{
-		ID:                deploymentID,
-		Name:              req.Name,
-		ProjectID:         req.ProjectID,
-		DeploymentImageID: req.DeploymentImageID,
-		ContainerImage:    req.ContainerImage,
-		MinReplicas:       req.MinReplicas,
-		MaxReplicas:       req.MaxReplicas,
-		VMID:              req.VMID,
-		Annotations:       req.Annotations,
-		AuthUsername:      req.AuthUsername,
-		AuthPassword:      req.AuthPassword,
-		URL:               deploymentURL,
+		ID:                   deploymentID,
+		Name:                 req.Name,
+		ProjectID:            req.ProjectID,
+		DeploymentImageID:    req.DeploymentImageID,
+		ContainerImage:       req.ContainerImage,
+		RegistryCredentialID: req.RegistryCredentialID,
+		ContainerPort:        req.ContainerPort,
+		ContainerArgs:        req.ContainerArgs,
+		EnvVars:              req.EnvVars,
+		SecretRefs:           req.SecretRefs,
+		VolumeMounts:         req.VolumeMounts,
+		MinReplicas:          req.MinReplicas,
+		MaxReplicas:          req.MaxReplicas,
+		Autoscaling:          req.Autoscaling,
+		Paused:               &req.Paused,
+		VMID:                 req.VMID,
+		Annotations:          req.Annotations,
+		AuthUsername:         req.AuthUsername,
+		AuthPassword:         req.AuthPassword,
+		URL:                  deploymentURL,
 	}, nil
 }
 
@@ -116,23 +207,37 @@ func extractDeploymentURL(body string) string {
 }
 
 func extractDeploymentID(url string) (string, error) {
-	// URL format is {name}-{id}.{rest_of_theta_domain}
-	parts := strings.Split(url, ".")
-	if len(parts) < 2 {
-		return "", fmt.Errorf("unexpected URL format")
+	_, id, _, err := parseDeploymentURL(url)
+	return id, err
+}
+
+// parseDeploymentURL splits a deployment URL into the deployment name, its ID and the host.
+// The scheme is optional, the host has the format {name}-{id}.{rest_of_theta_domain}.
+func parseDeploymentURL(deploymentURL string) (name, id, host string, err error) {
+	raw := strings.TrimSpace(deploymentURL)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	parsed, err := neturl.Parse(raw)
+	if err != nil {
+		return "", "", "", fmt.Errorf("unexpected URL format: %v", err)
 	}
 
 	// Extract the part before the first dot
-	beforeDot := parts[0]
+	host = parsed.Hostname()
+	label, _, found := strings.Cut(host, ".")
+	if !found {
+		return "", "", "", fmt.Errorf("unexpected URL format")
+	}
 
-	// Extract the ID from the part before the dot
-	parts = strings.Split(beforeDot, "-")
-	if len(parts) < 2 {
-		return "", fmt.Errorf("unexpected URL format for ID extraction")
+	// The ID is the part after the last hyphen, names can contain hyphens themselves
+	sep := strings.LastIndex(label, "-")
+	if sep <= 0 || sep == len(label)-1 {
+		return "", "", "", fmt.Errorf("unexpected URL format for ID extraction")
 	}
 
-	// The ID is the part after the name
-	return parts[len(parts)-1], nil
+	return label[:sep], label[sep+1:], host, nil
 }
 
 func (c *Client) GetDeploymentByID(id string, projectID string) (*Deployment, error) {
@@ -168,18 +273,32 @@ func (c *Client) GetDeploymentByID(id string, projectID string) (*Deployment, er
 		if suffix, ok := deploymentData["Suffix"].(string); ok && suffix == id {
 			// Create and populate Deployment struct
 			deployment := &Deployment{
-				ID:                suffix,
-				Name:              getStringValue(deploymentData, "Name"),
-				ProjectID:         getStringValue(deploymentData, "ProjectID"),
-				DeploymentImageID: getStringValue(deploymentData, "DeploymentImageID"),
-				ContainerImage:    getStringValue(deploymentData, "ImageURL"),
-				MinReplicas:       1,
-				MaxReplicas:       getInt64Value(deploymentData, "Replicas"),
-				VMID:              getStringValue(deploymentData, "MachineType"),
-				Annotations:       convertToStringMap(getMapValue(deploymentData, "Annotations")),
-				AuthUsername:      getStringValue(deploymentData, "AuthUsername"),
-				AuthPassword:      getStringValue(deploymentData, "AuthPassword"),
-				URL:               getStringValue(deploymentData, "Endpoint"),
+				ID:                   suffix,
+				Name:                 getStringValue(deploymentData, "Name"),
+				ProjectID:            getStringValue(deploymentData, "ProjectID"),
+				DeploymentImageID:    getStringValue(deploymentData, "DeploymentImageID"),
+				ContainerImage:       getStringValue(deploymentData, "ImageURL"),
+				RegistryCredentialID: getStringValue(deploymentData, "RegistryCredentialID"),
+				ContainerPort:        getInt64Value(deploymentData, "ContainerPort"),
+				ContainerArgs:        getStringSliceValue(deploymentData, "ContainerArgs"),
+				EnvVars:              getStringMapValue(deploymentData, "EnvVars"),
+				SecretRefs:           getStringMapValue(deploymentData, "SecretRefs"),
+				VolumeMounts:         getDeploymentVolumeMounts(deploymentData, "VolumeMounts"),
+				MinReplicas:          getInt64ValueOr(deploymentData, "MinReplicas", replicasNotReported),
+				MaxReplicas:          getInt64Value(deploymentData, "Replicas"),
+				Autoscaling:          getDeploymentAutoscaling(deploymentData, "Autoscaling"),
+				Paused:               getBoolPtr(deploymentData, "Paused"),
+				VMID:                 getStringValue(deploymentData, "MachineType"),
+				Annotations:          convertToStringMap(getMapValue(deploymentData, "Annotations")),
+				AuthUsername:         getStringValue(deploymentData, "AuthUsername"),
+				AuthPassword:         getStringValue(deploymentData, "AuthPassword"),
+				URL:                  getStringValue(deploymentData, "Endpoint"),
+				Status:               getStringValue(deploymentData, "Status"),
+				RunningReplicas:      getInt64Value(deploymentData, "ReadyReplicas"),
+				StatusMessage:        getStringValue(deploymentData, "Message"),
+				CreatedAt:            getStringValue(deploymentData, "CreateTime"),
+				MachineTypeDetails:   getStringMapValue(deploymentData, "MachineTypeDetails"),
+				Replicas:             getDeploymentReplicas(deploymentData, "Pods"),
 			}
 
 			return deployment, nil
@@ -187,7 +306,76 @@ func (c *Client) GetDeploymentByID(id string, projectID string) (*Deployment, er
 	}
 
 	// Deployment not found
-	return nil, fmt.Errorf("Deployment not found")
+	return nil, errDeploymentNotFound
+}
+
+// WaitForDeploymentReady polls the controller until the deployment has running replicas,
+// returning an error if the controller reports a failure or the context expires first
+func (c *Client) WaitForDeploymentReady(ctx context.Context, id string, projectID string) (*Deployment, error) {
+	for {
+		deployment, err := c.GetDeploymentByID(id, projectID)
+		// a freshly created deployment can take a moment to show up in the list
+		if err != nil && !errors.Is(err, errNotFound) {
+			return nil, err
+		}
+
+		if deployment != nil {
+			if isDeploymentFailed(deployment) {
+				return deployment, fmt.Errorf("deployment %s failed with status %s: %s", id, deployment.Status, deployment.StatusMessage)
+			}
+			if isDeploymentReady(deployment) {
+				return deployment, nil
+			}
+			log.Printf("DEBUG: Deployment %s is %s with %d running replicas, waiting", id, deployment.Status, deployment.RunningReplicas)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for deployment %s to become ready: %v", id, ctx.Err())
+		case <-time.After(deploymentPollInterval):
+		}
+	}
+}
+
+// WaitForDeploymentDeleted polls the controller until the deployment no longer shows up in the project
+func (c *Client) WaitForDeploymentDeleted(ctx context.Context, id string, projectID string) error {
+	for {
+		_, err := c.GetDeploymentByID(id, projectID)
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		log.Printf("DEBUG: Deployment %s still exists, waiting", id)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for deployment %s to be deleted: %v", id, ctx.Err())
+		case <-time.After(deploymentPollInterval):
+		}
+	}
+}
+
+func isDeploymentReady(deployment *Deployment) bool {
+	if deployment.RunningReplicas > 0 {
+		return true
+	}
+
+	switch strings.ToLower(deployment.Status) {
+	case "running", "ready", "active":
+		return true
+	}
+	return false
+}
+
+func isDeploymentFailed(deployment *Deployment) bool {
+	switch strings.ToLower(deployment.Status) {
+	case "failed", "error", "crashloopbackoff", "imagepullbackoff", "errimagepull":
+		return true
+	}
+	return false
 }
 
 // Utility function to safely get a string value from a map
@@ -206,6 +394,22 @@ func getInt64Value(data map[string]interface{}, key string) int64 {
 	return 0
 }
 
+// Utility function to safely get an int64 value from a map, fallback when the key is missing
+func getInt64ValueOr(data map[string]interface{}, key string, fallback int64) int64 {
+	if value, ok := data[key].(float64); ok {
+		return int64(value)
+	}
+	return fallback
+}
+
+// Utility function to get a bool value from a map, nil when the key is missing
+func getBoolPtr(data map[string]interface{}, key string) *bool {
+	if value, ok := data[key].(bool); ok {
+		return &value
+	}
+	return nil
+}
+
 // Utility function to safely get a map value from a map
 func getMapValue(data map[string]interface{}, key string) map[string]interface{} {
 	if value, ok := data[key].(map[string]interface{}); ok {
@@ -214,6 +418,95 @@ func getMapValue(data map[string]interface{}, key string) map[string]interface{}
 	return nil
 }
 
+// Utility function to safely get a string slice from a map, nil when the key is missing
+func getStringSliceValue(data map[string]interface{}, key string) []string {
+	values, ok := data[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, fmt.Sprintf("%v", value))
+	}
+	return result
+}
+
+// Utility function to read the pods listed for a deployment
+func getDeploymentReplicas(data map[string]interface{}, key string) []DeploymentReplica {
+	pods, ok := data[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	replicas := make([]DeploymentReplica, 0, len(pods))
+	for _, item := range pods {
+		pod, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		ready, _ := pod["Ready"].(bool)
+		replicas = append(replicas, DeploymentReplica{
+			Name:         getStringValue(pod, "Name"),
+			Status:       getStringValue(pod, "Status"),
+			Ready:        ready,
+			RestartCount: getInt64Value(pod, "RestartCount"),
+			StartedAt:    getStringValue(pod, "StartTime"),
+		})
+	}
+	return replicas
+}
+
+// Utility function to read the scaling configuration of a deployment, nil when it has none
+func getDeploymentAutoscaling(data map[string]interface{}, key string) *DeploymentAutoscaling {
+	autoscaling := getMapValue(data, key)
+	if autoscaling == nil || getStringValue(autoscaling, "Metric") == "" {
+		return nil
+	}
+
+	return &DeploymentAutoscaling{
+		Metric:                        getStringValue(autoscaling, "Metric"),
+		Target:                        getInt64Value(autoscaling, "Target"),
+		ScaleUpCooldownSeconds:        getInt64Value(autoscaling, "ScaleUpCooldownSeconds"),
+		ScaleDownCooldownSeconds:      getInt64Value(autoscaling, "ScaleDownCooldownSeconds"),
+		ScaleToZeroIdleTimeoutSeconds: getInt64Value(autoscaling, "ScaleToZeroIdleTimeoutSeconds"),
+	}
+}
+
+// Utility function to read the volumes mounted into a deployment
+func getDeploymentVolumeMounts(data map[string]interface{}, key string) []DeploymentVolumeMount {
+	items, ok := data[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	mounts := make([]DeploymentVolumeMount, 0, len(items))
+	for _, item := range items {
+		mount, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		readOnly, _ := mount["ReadOnly"].(bool)
+		mounts = append(mounts, DeploymentVolumeMount{
+			VolumeID:  getStringValue(mount, "VolumeID"),
+			MountPath: getStringValue(mount, "MountPath"),
+			ReadOnly:  readOnly,
+		})
+	}
+	return mounts
+}
+
+// Utility function to safely get a string map from a map, nil when the key is missing
+func getStringMapValue(data map[string]interface{}, key string) map[string]string {
+	value := getMapValue(data, key)
+	if value == nil {
+		return nil
+	}
+	return convertToStringMap(value)
+}
+
 func convertToStringMap(input map[string]interface{}) map[string]string {
 	result := make(map[string]string)
 	for key, value := range input {
@@ -262,7 +555,7 @@ func (c *Client) DeleteDeployment(id string, projectID string) (bool, error) {
 	// Send the request using the utility function
 	respBody, err := sendRequest(c, "DELETE", url, nil)
 	if err != nil {
-		return false, fmt.Errorf("failed to send request: %v", err)
+		return false, fmt.Errorf("failed to send request: %w", err)
 	}
 
 	// Process the response body
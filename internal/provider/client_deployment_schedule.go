@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DeploymentScheduleRule sets the replica bounds of the deployment whenever its cron expression fires
+type DeploymentScheduleRule struct {
+	Cron        string `json:"cron"`
+	MinReplicas int64  `json:"min_replicas"`
+	MaxReplicas int64  `json:"max_replicas"`
+}
+
+type DeploymentSchedule struct {
+	DeploymentID string                   `json:"deployment_id"`
+	ProjectID    string                   `json:"project_id"`
+	TimeZone     string                   `json:"time_zone"`
+	Rules        []DeploymentScheduleRule `json:"rules"`
+}
+
+// PutDeploymentSchedule creates the schedule of the deployment or replaces the existing one
+func (c *Client) PutDeploymentSchedule(schedule DeploymentSchedule) (*DeploymentSchedule, error) {
+	url := fmt.Sprintf("%s/deployment/%s/schedule?project_id=%s", c.baseControllerURL, schedule.DeploymentID, schedule.ProjectID)
+
+	jsonData, err := json.Marshal(schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, "PUT", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeDeploymentScheduleResponse(body)
+}
+
+func (c *Client) GetDeploymentSchedule(deploymentID, projectID string) (*DeploymentSchedule, error) {
+	url := fmt.Sprintf("%s/deployment/%s/schedule?project_id=%s", c.baseControllerURL, deploymentID, projectID)
+
+	body, err := sendRequest(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeDeploymentScheduleResponse(body)
+}
+
+func (c *Client) DeleteDeploymentSchedule(deploymentID, projectID string) error {
+	url := fmt.Sprintf("%s/deployment/%s/schedule?project_id=%s", c.baseControllerURL, deploymentID, projectID)
+
+	body, err := sendRequest(c, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return nil
+}
+
+func decodeDeploymentScheduleResponse(body []byte) (*DeploymentSchedule, error) {
+	var respData struct {
+		Status string              `json:"status"`
+		Body   *DeploymentSchedule `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	// deployments without a schedule have an empty body
+	if respData.Body == nil || len(respData.Body.Rules) == 0 {
+		return nil, fmt.Errorf("deployment schedule %w", errNotFound)
+	}
+
+	return respData.Body, nil
+}
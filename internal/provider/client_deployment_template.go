@@ -2,58 +2,48 @@ package provider
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
-
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
-type DeploymentTemplateRequest struct {
-	ID              basetypes.StringValue `json:"id,omitempty" tfsdk:"id"`
-	Name            string                `json:"name" tfsdk:"name"`
-	ProjectID       string                `json:"project_id" tfsdk:"project_id"`
-	Description     basetypes.StringValue `json:"description,omitempty" tfsdk:"description"`
-	ContainerImages []string              `json:"container_images" tfsdk:"container_images"`
-	ContainerPort   basetypes.Int64Value  `json:"container_port,omitempty" tfsdk:"container_port"`
-	ContainerArgs   []string              `json:"container_args,omitempty" tfsdk:"container_args"`
-	EnvVars         map[string]string     `json:"env_vars,omitempty" tfsdk:"env_vars"`
-	Tags            []string              `json:"tags,omitempty" tfsdk:"tags"`
-	IconURL         basetypes.StringValue `json:"icon_url,omitempty" tfsdk:"icon_url"`
-	RequireEnvVars  basetypes.BoolValue   `json:"require_env_vars,omitempty" tfsdk:"require_env_vars"`
-	Rank            basetypes.Int64Value  `json:"rank,omitempty" tfsdk:"rank"`
-	CreateTime      basetypes.StringValue `json:"create_time,omitempty" tfsdk:"create_time"`
-	Category        basetypes.StringValue `json:"category,omitempty" tfsdk:"category"`
-}
+// deploymentTemplatePageSize is the number of templates requested per page when looking templates up
+const deploymentTemplatePageSize = 100
 
 type DeploymentTemplateRequestNative struct {
-	Name           string            `json:"name"`
-	ProjectID      string            `json:"project_id"`
-	Description    string            `json:"description,omitempty"`
-	ContainerImage []string          `json:"container_image"`
-	ContainerPort  int64             `json:"container_port,omitempty"`
-	ContainerArgs  []string          `json:"container_args,omitempty"`
-	EnvVars        map[string]string `json:"env_vars,omitempty"`
-	Tags           []string          `json:"tags,omitempty"`
-	IconURL        string            `json:"icon_url,omitempty"`
-	RequireEnvVars *bool             `json:"require_env_vars,omitempty"`
-	Rank           *int64            `json:"rank,omitempty"`
+	Name                 string            `json:"name"`
+	ProjectID            string            `json:"project_id"`
+	Description          string            `json:"description,omitempty"`
+	ContainerImage       []string          `json:"container_image"`
+	RegistryCredentialID string            `json:"registry_credential_id,omitempty"`
+	ContainerPort        int64             `json:"container_port,omitempty"`
+	ContainerArgs        []string          `json:"container_args,omitempty"`
+	EnvVars              map[string]string `json:"env_vars,omitempty"`
+	SecretRefs           map[string]string `json:"secret_refs,omitempty"`
+	Tags                 []string          `json:"tags,omitempty"`
+	IconURL              string            `json:"icon_url,omitempty"`
+	RequireEnvVars       *bool             `json:"require_env_vars,omitempty"`
+	Rank                 *int64            `json:"rank,omitempty"`
 }
 
 type DeploymentTemplate struct {
-	ID              string            `json:"id"`
-	Name            string            `json:"name"`
-	Description     string            `json:"description"`
-	Tags            []string          `json:"tags"`
-	Category        string            `json:"category"`
-	ProjectID       string            `json:"project_id"`
-	ContainerImages []string          `json:"container_images"`
-	ContainerPort   int64             `json:"container_port"`
-	ContainerArgs   []string          `json:"container_args"`
-	EnvVars         map[string]string `json:"env_vars"`
-	RequireEnvVars  *bool             `json:"require_env_vars"`
-	Rank            *int64            `json:"rank"`
-	IconURL         string            `json:"icon_url"`
-	CreateTime      time.Time         `json:"create_time"`
+	ID                   string            `json:"id"`
+	Name                 string            `json:"name"`
+	Description          string            `json:"description"`
+	Tags                 []string          `json:"tags"`
+	Category             string            `json:"category"`
+	ProjectID            string            `json:"project_id"`
+	ContainerImages      []string          `json:"container_images"`
+	RegistryCredentialID string            `json:"registry_credential_id"`
+	ContainerPort        int64             `json:"container_port"`
+	ContainerArgs        []string          `json:"container_args"`
+	EnvVars              map[string]string `json:"env_vars"`
+	SecretRefs           map[string]string `json:"secret_refs"`
+	RequireEnvVars       *bool             `json:"require_env_vars"`
+	Rank                 *int64            `json:"rank"`
+	IconURL              string            `json:"icon_url"`
+	CreateTime           time.Time         `json:"create_time"`
 }
 
 type CreateDeploymentTemplateResponse struct {
@@ -124,11 +114,46 @@ func (c *Client) UpdateDeploymentTemplate(templateID string, template Deployment
 }
 
 func (c *Client) GetDeploymentTemplates(projectID string, page, number int) ([]DeploymentTemplate, error) {
-	url := fmt.Sprintf("https://controller.thetaedgecloud.com/deployment_template/list_custom_templates?project_id=%s&page=%d&number=%d", projectID, page, number)
+	templates, _, err := c.getDeploymentTemplatesPage(customDeploymentTemplatesURL(projectID, page, number))
+	return templates, err
+}
+
+func (c *Client) GetStandardDeploymentTemplates(page, number int) ([]DeploymentTemplate, error) {
+	templates, _, err := c.getDeploymentTemplatesPage(standardDeploymentTemplatesURL(page, number))
+	return templates, err
+}
+
+func customDeploymentTemplatesURL(projectID string, page, number int) string {
+	return fmt.Sprintf("https://controller.thetaedgecloud.com/deployment_template/list_custom_templates?project_id=%s&page=%d&number=%d", projectID, page, number)
+}
+
+func standardDeploymentTemplatesURL(page, number int) string {
+	return fmt.Sprintf("https://controller.thetaedgecloud.com/deployment_template/list_standard_templates?page=%d&number=%d", page, number)
+}
+
+// getAllDeploymentTemplates reads every page of a template listing, until total_count templates were read
+func (c *Client) getAllDeploymentTemplates(pageURL func(page, number int) string) ([]DeploymentTemplate, error) {
+	var all []DeploymentTemplate
+	for page := 0; ; page++ {
+		templates, totalCount, err := c.getDeploymentTemplatesPage(pageURL(page, deploymentTemplatePageSize))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, templates...)
+
+		// a short page is the last one, which also ends the listing when total_count is missing
+		if len(templates) < deploymentTemplatePageSize || (totalCount >= 0 && len(all) >= totalCount) {
+			return all, nil
+		}
+	}
+}
 
+// getDeploymentTemplatesPage returns one page of templates and the total number of templates,
+// which is -1 when the response doesn't include it
+func (c *Client) getDeploymentTemplatesPage(url string) ([]DeploymentTemplate, int, error) {
 	body, err := sendRequest(c, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var respData struct {
@@ -141,18 +166,25 @@ func (c *Client) GetDeploymentTemplates(projectID string, page, number int) ([]D
 		} `json:"body"`
 	}
 	if err := json.Unmarshal(body, &respData); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	if respData.Status != "success" {
-		return nil, fmt.Errorf("API response error: %s", respData.Status)
+		return nil, 0, fmt.Errorf("API response error: %s", respData.Status)
 	}
 
-	return respData.Body.Templates, nil
+	totalCount, err := strconv.Atoi(respData.Body.TotalCount)
+	if err != nil {
+		totalCount = -1
+	}
+
+	return respData.Body.Templates, totalCount, nil
 }
 
 func (c *Client) GetDeploymentTemplateByID(projectID, templateID string) (*DeploymentTemplate, error) {
-	templates, err := c.GetDeploymentTemplates(projectID, 0, 100)
+	templates, err := c.getAllDeploymentTemplates(func(page, number int) string {
+		return customDeploymentTemplatesURL(projectID, page, number)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +195,55 @@ func (c *Client) GetDeploymentTemplateByID(projectID, templateID string) (*Deplo
 		}
 	}
 
-	return nil, fmt.Errorf("template with ID %s not found", templateID)
+	return nil, fmt.Errorf("template with ID %s %w", templateID, errNotFound)
+}
+
+// FindDeploymentTemplate looks the template up among the custom templates of the project first,
+// then among the standard templates available to every project
+func (c *Client) FindDeploymentTemplate(projectID, templateID string) (*DeploymentTemplate, error) {
+	template, err := c.GetDeploymentTemplateByID(projectID, templateID)
+	if err == nil || !errors.Is(err, errNotFound) {
+		return template, err
+	}
+
+	templates, err := c.getAllDeploymentTemplates(standardDeploymentTemplatesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates {
+		if template.ID == templateID {
+			return &template, nil
+		}
+	}
+
+	return nil, fmt.Errorf("template with ID %s %w", templateID, errNotFound)
+}
+
+func (c *Client) GetDeploymentTemplateByName(projectID, name string) (*DeploymentTemplate, error) {
+	templates, err := c.getAllDeploymentTemplates(func(page, number int) string {
+		return customDeploymentTemplatesURL(projectID, page, number)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var found *DeploymentTemplate
+	for i := range templates {
+		if templates[i].Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("multiple templates named %s found in project %s, use the template ID instead", name, projectID)
+		}
+		found = &templates[i]
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("template with name %s %w", name, errNotFound)
+	}
+
+	return found, nil
 }
 
 func (c *Client) DeleteDeploymentTemplate(templateID, projectID string) (bool, error) {
@@ -0,0 +1,81 @@
+package provider
+
+import "testing"
+
+func TestParseDeploymentURL(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		wantName string
+		wantID   string
+		wantHost string
+		wantErr  bool
+	}{
+		"valid": {
+			url:      "https://vllm-abc123.tec-s1.onthetaedgecloud.com",
+			wantName: "vllm",
+			wantID:   "abc123",
+			wantHost: "vllm-abc123.tec-s1.onthetaedgecloud.com",
+		},
+		"name with hyphens and path": {
+			url:      "https://my-llama-server-abc123.tec-s1.onthetaedgecloud.com/v1/models",
+			wantName: "my-llama-server",
+			wantID:   "abc123",
+			wantHost: "my-llama-server-abc123.tec-s1.onthetaedgecloud.com",
+		},
+		"without scheme and with whitespace": {
+			url:      "  vllm-abc123.tec-s1.onthetaedgecloud.com\n",
+			wantName: "vllm",
+			wantID:   "abc123",
+			wantHost: "vllm-abc123.tec-s1.onthetaedgecloud.com",
+		},
+		"with port": {
+			url:      "http://vllm-abc123.tec-s1.onthetaedgecloud.com:8000",
+			wantName: "vllm",
+			wantID:   "abc123",
+			wantHost: "vllm-abc123.tec-s1.onthetaedgecloud.com",
+		},
+		"empty": {
+			url:     "",
+			wantErr: true,
+		},
+		"no domain": {
+			url:     "https://vllm-abc123",
+			wantErr: true,
+		},
+		"no hyphen": {
+			url:     "https://vllm.tec-s1.onthetaedgecloud.com",
+			wantErr: true,
+		},
+		"missing name": {
+			url:     "https://-abc123.tec-s1.onthetaedgecloud.com",
+			wantErr: true,
+		},
+		"missing id": {
+			url:     "https://vllm-.tec-s1.onthetaedgecloud.com",
+			wantErr: true,
+		},
+		"invalid url": {
+			url:     "https://vllm-abc123.tec-s1.onthetaedgecloud.com/%zz",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotName, gotID, gotHost, err := parseDeploymentURL(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got name %q, id %q and host %q", gotName, gotID, gotHost)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if gotName != tt.wantName || gotID != tt.wantID || gotHost != tt.wantHost {
+				t.Errorf("got name %q, id %q and host %q, want %q, %q and %q", gotName, gotID, gotHost, tt.wantName, tt.wantID, tt.wantHost)
+			}
+		})
+	}
+}
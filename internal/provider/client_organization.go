@@ -90,3 +90,81 @@ func (c *Client) GetOrganizations() ([]Organization, error) {
 
 	return respData.Body.Organizations, nil
 }
+
+type OrganizationRequest struct {
+	Name    string `json:"name"`
+	Email   string `json:"email,omitempty"`
+	LogoURL string `json:"logo_url"`
+}
+
+// GetOrganization returns one of the organizations of the authenticated user
+func (c *Client) GetOrganization(orgID string) (*Organization, error) {
+	organizations, err := c.GetOrganizations()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, organization := range organizations {
+		if organization.ID == orgID {
+			return &organization, nil
+		}
+	}
+
+	return nil, fmt.Errorf("organization %s %w", orgID, errNotFound)
+}
+
+func (c *Client) CreateOrganization(organization OrganizationRequest) (*Organization, error) {
+	url := fmt.Sprintf("%s/organization", c.baseURL)
+
+	jsonData, err := json.Marshal(organization)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, "POST", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string       `json:"status"`
+		Body   Organization `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
+
+func (c *Client) UpdateOrganization(orgID string, organization OrganizationRequest) (*Organization, error) {
+	url := fmt.Sprintf("%s/organization/%s", c.baseURL, orgID)
+
+	jsonData, err := json.Marshal(organization)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, "PUT", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string       `json:"status"`
+		Body   Organization `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
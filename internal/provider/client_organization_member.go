@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+const organizationMemberPollInterval = 15 * time.Second
+
+type OrganizationMember struct {
+	UserID    string  `json:"id"`
+	Email     string  `json:"email"`
+	FirstName string  `json:"first_name"`
+	LastName  *string `json:"last_name"`
+	Role      string  `json:"role"`
+	JoinTime  string  `json:"join_time"`
+}
+
+// OrganizationInvitation is an invitation for someone to join an organization, which stays pending until accepted
+type OrganizationInvitation struct {
+	ID         string `json:"id"`
+	Email      string `json:"email"`
+	Role       string `json:"role"`
+	Status     string `json:"status"`
+	CreateTime string `json:"create_time"`
+}
+
+type OrganizationInvitationRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// Invitation statuses
+const (
+	invitationStatusPending  = "pending"
+	invitationStatusAccepted = "accepted"
+)
+
+func (c *Client) GetOrganizationMembers(orgID string) ([]OrganizationMember, error) {
+	url := fmt.Sprintf("%s/organization/%s/users", c.baseURL, orgID)
+
+	body, err := sendRequest(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+		Body   struct {
+			Users []OrganizationMember `json:"users"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return respData.Body.Users, nil
+}
+
+// FindOrganizationMember looks the member up by user ID, or by email when the user ID is empty
+func (c *Client) FindOrganizationMember(orgID, userID, email string) (*OrganizationMember, error) {
+	members, err := c.GetOrganizationMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if userID != "" && member.UserID == userID {
+			return &member, nil
+		}
+		if userID == "" && strings.EqualFold(member.Email, email) {
+			return &member, nil
+		}
+	}
+
+	return nil, fmt.Errorf("member of organization %s %w", orgID, errNotFound)
+}
+
+// WaitForOrganizationMember polls the organization until the user shows up as a member, which happens once they accept their invitation
+func (c *Client) WaitForOrganizationMember(ctx context.Context, orgID, userID, email string) (*OrganizationMember, error) {
+	user := userID
+	if user == "" {
+		user = email
+	}
+
+	for {
+		member, err := c.FindOrganizationMember(orgID, userID, email)
+		if err == nil {
+			return member, nil
+		}
+		if !errors.Is(err, errNotFound) {
+			return nil, err
+		}
+
+		log.Printf("DEBUG: User %s is not a member of organization %s yet, waiting", user, orgID)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for %s to accept the invitation to organization %s: %v", user, orgID, ctx.Err())
+		case <-time.After(organizationMemberPollInterval):
+		}
+	}
+}
+
+func (c *Client) UpdateOrganizationMemberRole(orgID, userID, role string) (*OrganizationMember, error) {
+	url := fmt.Sprintf("%s/organization/%s/user/%s", c.baseURL, orgID, userID)
+
+	jsonData, err := json.Marshal(map[string]string{"role": role})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, "PUT", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string             `json:"status"`
+		Body   OrganizationMember `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
+
+func (c *Client) RemoveOrganizationMember(orgID, userID string) error {
+	url := fmt.Sprintf("%s/organization/%s/user/%s", c.baseURL, orgID, userID)
+
+	body, err := sendRequest(c, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return nil
+}
+
+func (c *Client) CreateOrganizationInvitation(orgID string, invitation OrganizationInvitationRequest) (*OrganizationInvitation, error) {
+	url := fmt.Sprintf("%s/organization/%s/invitation", c.baseURL, orgID)
+
+	jsonData, err := json.Marshal(invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, "POST", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string                 `json:"status"`
+		Body   OrganizationInvitation `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
+
+func (c *Client) GetOrganizationInvitations(orgID string) ([]OrganizationInvitation, error) {
+	url := fmt.Sprintf("%s/organization/%s/invitations", c.baseURL, orgID)
+
+	body, err := sendRequest(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+		Body   struct {
+			Invitations []OrganizationInvitation `json:"invitations"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return respData.Body.Invitations, nil
+}
+
+func (c *Client) GetOrganizationInvitation(orgID, invitationID string) (*OrganizationInvitation, error) {
+	invitations, err := c.GetOrganizationInvitations(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, invitation := range invitations {
+		if invitation.ID == invitationID {
+			return &invitation, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invitation %s of organization %s %w", invitationID, orgID, errNotFound)
+}
+
+func (c *Client) DeleteOrganizationInvitation(orgID, invitationID string) error {
+	url := fmt.Sprintf("%s/organization/%s/invitation/%s", c.baseURL, orgID, invitationID)
+
+	body, err := sendRequest(c, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return nil
+}
@@ -166,9 +166,75 @@ func (c *Client) DeleteProject(id string) error {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("project %s %w", id, errNotFound)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("API request error: %s", resp.Status)
 	}
 
 	return nil
 }
+
+func (c *Client) GetProject(projectID string) (*Project, error) {
+	url := fmt.Sprintf("%s/project/%s", c.baseURL, projectID)
+
+	body, err := sendRequest(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string  `json:"status"`
+		Body   Project `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
+
+// EnableProjectGateway turns on the API gateway of the project, which creates its key and secret
+func (c *Client) EnableProjectGateway(projectID string) (*Project, error) {
+	url := fmt.Sprintf("%s/project/%s/gateway", c.baseURL, projectID)
+	return c.sendProjectGatewayRequest("POST", url)
+}
+
+// RotateProjectGatewaySecret replaces the gateway secret of the project, the key stays the same
+func (c *Client) RotateProjectGatewaySecret(projectID string) (*Project, error) {
+	url := fmt.Sprintf("%s/project/%s/gateway/secret", c.baseURL, projectID)
+	return c.sendProjectGatewayRequest("PUT", url)
+}
+
+func (c *Client) DisableProjectGateway(projectID string) error {
+	url := fmt.Sprintf("%s/project/%s/gateway", c.baseURL, projectID)
+	_, err := c.sendProjectGatewayRequest("DELETE", url)
+	return err
+}
+
+func (c *Client) sendProjectGatewayRequest(method, url string) (*Project, error) {
+	body, err := sendRequest(c, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string  `json:"status"`
+		Body   Project `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
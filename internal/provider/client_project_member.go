@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type ProjectMember struct {
+	UserID    string  `json:"id"`
+	Email     string  `json:"email"`
+	FirstName string  `json:"first_name"`
+	LastName  *string `json:"last_name"`
+	Role      string  `json:"role"`
+	JoinTime  string  `json:"join_time"`
+}
+
+// ProjectMemberRequest adds a user to a project, either an existing user by ID or anyone by email
+type ProjectMemberRequest struct {
+	UserID string `json:"user_id,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Role   string `json:"role"`
+}
+
+func (c *Client) GetProjectMembers(projectID string) ([]ProjectMember, error) {
+	url := fmt.Sprintf("%s/project/%s/users", c.baseURL, projectID)
+
+	body, err := sendRequest(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+		Body   struct {
+			Users []ProjectMember `json:"users"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return respData.Body.Users, nil
+}
+
+func (c *Client) GetProjectMember(projectID, userID string) (*ProjectMember, error) {
+	members, err := c.GetProjectMembers(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if member.UserID == userID {
+			return &member, nil
+		}
+	}
+
+	return nil, fmt.Errorf("member %s of project %s %w", userID, projectID, errNotFound)
+}
+
+// FindProjectMember looks the member up by user ID, or by email when the user ID is empty
+func (c *Client) FindProjectMember(projectID, userID, email string) (*ProjectMember, error) {
+	members, err := c.GetProjectMembers(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if userID != "" && member.UserID == userID {
+			return &member, nil
+		}
+		if userID == "" && strings.EqualFold(member.Email, email) {
+			return &member, nil
+		}
+	}
+
+	return nil, fmt.Errorf("member of project %s %w", projectID, errNotFound)
+}
+
+func (c *Client) AddProjectMember(projectID string, member ProjectMemberRequest) (*ProjectMember, error) {
+	url := fmt.Sprintf("%s/project/%s/user", c.baseURL, projectID)
+
+	jsonData, err := json.Marshal(member)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, "POST", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string        `json:"status"`
+		Body   ProjectMember `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
+
+func (c *Client) UpdateProjectMemberRole(projectID, userID, role string) (*ProjectMember, error) {
+	url := fmt.Sprintf("%s/project/%s/user/%s", c.baseURL, projectID, userID)
+
+	jsonData, err := json.Marshal(map[string]string{"role": role})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, "PUT", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string        `json:"status"`
+		Body   ProjectMember `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
+
+func (c *Client) RemoveProjectMember(projectID, userID string) error {
+	url := fmt.Sprintf("%s/project/%s/user/%s", c.baseURL, projectID, userID)
+
+	body, err := sendRequest(c, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return nil
+}
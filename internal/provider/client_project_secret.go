@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ProjectSecret is a named secret stored in a project. Its value is write-only, the API never returns it.
+type ProjectSecret struct {
+	Name       string `json:"name"`
+	ProjectID  string `json:"project_id"`
+	CreateTime string `json:"create_time"`
+	UpdateTime string `json:"update_time"`
+}
+
+type ProjectSecretRequest struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value"`
+}
+
+func (c *Client) GetProjectSecrets(projectID string) ([]ProjectSecret, error) {
+	url := fmt.Sprintf("%s/project/%s/secrets", c.baseURL, projectID)
+
+	body, err := sendRequest(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+		Body   struct {
+			Secrets []ProjectSecret `json:"secrets"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return respData.Body.Secrets, nil
+}
+
+func (c *Client) GetProjectSecret(projectID, name string) (*ProjectSecret, error) {
+	secrets, err := c.GetProjectSecrets(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, secret := range secrets {
+		if secret.Name == name {
+			return &secret, nil
+		}
+	}
+
+	return nil, fmt.Errorf("secret %s of project %s %w", name, projectID, errNotFound)
+}
+
+func (c *Client) CreateProjectSecret(projectID string, secret ProjectSecretRequest) (*ProjectSecret, error) {
+	url := fmt.Sprintf("%s/project/%s/secret", c.baseURL, projectID)
+	return c.sendProjectSecretRequest("POST", url, secret)
+}
+
+// UpdateProjectSecret replaces the value of the secret, deployments referencing it pick the new value up when restarted
+func (c *Client) UpdateProjectSecret(projectID, name, value string) (*ProjectSecret, error) {
+	url := fmt.Sprintf("%s/project/%s/secret/%s", c.baseURL, projectID, name)
+	return c.sendProjectSecretRequest("PUT", url, ProjectSecretRequest{Value: value})
+}
+
+func (c *Client) DeleteProjectSecret(projectID, name string) error {
+	url := fmt.Sprintf("%s/project/%s/secret/%s", c.baseURL, projectID, name)
+
+	body, err := sendRequest(c, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return nil
+}
+
+func (c *Client) sendProjectSecretRequest(method, url string, secret ProjectSecretRequest) (*ProjectSecret, error) {
+	jsonData, err := json.Marshal(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// "value" is too generic to redact everywhere, so it's only redacted for secrets
+	body, err := sendRequest(c, method, url, jsonData, "value")
+	if err != nil {
+		return nil, err
+	}
+
+	var respData struct {
+		Status string        `json:"status"`
+		Body   ProjectSecret `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type RegistryCredentialRequest struct {
+	ProjectID string `json:"project_id"`
+	Registry  string `json:"registry"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+}
+
+// RegistryCredential lets the controller pull images from a private container registry.
+// The password is write-only, the API never returns it.
+type RegistryCredential struct {
+	ID         string `json:"id"`
+	ProjectID  string `json:"project_id"`
+	Registry   string `json:"registry"`
+	Username   string `json:"username"`
+	CreateTime string `json:"create_time"`
+}
+
+func (c *Client) CreateRegistryCredential(credential RegistryCredentialRequest) (*RegistryCredential, error) {
+	url := fmt.Sprintf("%s/registry_credential", c.baseControllerURL)
+	return c.sendRegistryCredentialRequest("POST", url, credential)
+}
+
+func (c *Client) GetRegistryCredential(credentialID, projectID string) (*RegistryCredential, error) {
+	url := fmt.Sprintf("%s/registry_credential/%s?project_id=%s", c.baseControllerURL, credentialID, projectID)
+
+	body, err := sendRequest(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRegistryCredentialResponse(body)
+}
+
+func (c *Client) UpdateRegistryCredential(credentialID string, credential RegistryCredentialRequest) (*RegistryCredential, error) {
+	url := fmt.Sprintf("%s/registry_credential/%s?project_id=%s", c.baseControllerURL, credentialID, credential.ProjectID)
+	return c.sendRegistryCredentialRequest("PUT", url, credential)
+}
+
+func (c *Client) DeleteRegistryCredential(credentialID, projectID string) error {
+	url := fmt.Sprintf("%s/registry_credential/%s?project_id=%s", c.baseControllerURL, credentialID, projectID)
+
+	body, err := sendRequest(c, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return nil
+}
+
+func (c *Client) sendRegistryCredentialRequest(method, url string, credential RegistryCredentialRequest) (*RegistryCredential, error) {
+	jsonData, err := json.Marshal(credential)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, method, url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRegistryCredentialResponse(body)
+}
+
+func decodeRegistryCredentialResponse(body []byte) (*RegistryCredential, error) {
+	var respData struct {
+		Status string             `json:"status"`
+		Body   RegistryCredential `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
@@ -1 +1,357 @@
 package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// How often the video API is polled while a video is encoded
+const videoPollInterval = 10 * time.Second
+
+// States of a video, encoding is finished once it reaches videoStateSuccess
+const (
+	videoStateSuccess = "success"
+	videoStateFailed  = "failed"
+)
+
+// VideoCredentials are the Theta Video API service account of a project
+type VideoCredentials struct {
+	ID     string
+	Secret string
+}
+
+type LivestreamFallback struct {
+	URL            string `json:"url"`
+	TimeoutSeconds int64  `json:"timeout_seconds,omitempty"`
+}
+
+type LivestreamRequest struct {
+	Name        string              `json:"name"`
+	Resolutions []string            `json:"resolutions,omitempty"`
+	Fallback    *LivestreamFallback `json:"fallback"`
+}
+
+type Livestream struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Status       string              `json:"status"`
+	StreamServer string              `json:"stream_server"`
+	StreamKey    string              `json:"stream_key"`
+	PlaybackURI  string              `json:"playback_uri"`
+	Resolutions  []string            `json:"resolutions"`
+	Fallback     *LivestreamFallback `json:"fallback"`
+	CreateTime   string              `json:"create_time"`
+}
+
+type VideoDRMRule struct {
+	ChainID       int64  `json:"chain_id"`
+	NFTCollection string `json:"nft_collection"`
+}
+
+type VideoRequest struct {
+	SourceUploadID string         `json:"source_upload_id"`
+	PlaybackPolicy string         `json:"playback_policy"`
+	Name           string         `json:"name,omitempty"`
+	Resolutions    []string       `json:"resolutions,omitempty"`
+	UseDRM         bool           `json:"use_drm"`
+	DRMRules       []VideoDRMRule `json:"drm_rules,omitempty"`
+}
+
+type Video struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	State       string         `json:"state"`
+	Progress    float64        `json:"progress"`
+	Error       *string        `json:"error"`
+	PlaybackURI *string        `json:"playback_uri"`
+	PlayerURI   *string        `json:"player_uri"`
+	Resolutions []string       `json:"resolutions"`
+	UseDRM      bool           `json:"use_drm"`
+	DRMRules    []VideoDRMRule `json:"drm_rules"`
+	CreateTime  string         `json:"create_time"`
+}
+
+// GetVideoCredentials returns the Theta Video API credentials of the project
+func (c *Client) GetVideoCredentials(projectID string) (*VideoCredentials, error) {
+	project, err := c.GetProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.TvaID == "" || project.TvaSecret == "" {
+		return nil, fmt.Errorf("project %s has no Theta Video API credentials", projectID)
+	}
+
+	return &VideoCredentials{ID: project.TvaID, Secret: project.TvaSecret}, nil
+}
+
+func sendVideoRequest(c *Client, credentials *VideoCredentials, method, url string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-tva-sa-id", credentials.ID)
+	req.Header.Set("x-tva-sa-secret", credentials.Secret)
+
+	return doRequest(c, req, body)
+}
+
+func (c *Client) CreateLivestream(credentials *VideoCredentials, stream LivestreamRequest) (*Livestream, error) {
+	url := fmt.Sprintf("%s/stream", c.baseVideoURL)
+
+	jsonData, err := json.Marshal(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendVideoRequest(c, credentials, "POST", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeLivestreamResponse(body)
+}
+
+func (c *Client) GetLivestream(credentials *VideoCredentials, streamID string) (*Livestream, error) {
+	url := fmt.Sprintf("%s/stream/%s", c.baseVideoURL, streamID)
+
+	body, err := sendVideoRequest(c, credentials, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeLivestreamResponse(body)
+}
+
+func (c *Client) UpdateLivestream(credentials *VideoCredentials, streamID string, stream LivestreamRequest) (*Livestream, error) {
+	url := fmt.Sprintf("%s/stream/%s", c.baseVideoURL, streamID)
+
+	jsonData, err := json.Marshal(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendVideoRequest(c, credentials, "PUT", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeLivestreamResponse(body)
+}
+
+func (c *Client) DeleteLivestream(credentials *VideoCredentials, streamID string) error {
+	url := fmt.Sprintf("%s/stream/%s", c.baseVideoURL, streamID)
+
+	body, err := sendVideoRequest(c, credentials, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return nil
+}
+
+// The Theta Video API wraps single streams in a list
+func decodeLivestreamResponse(body []byte) (*Livestream, error) {
+	var respData struct {
+		Status string `json:"status"`
+		Body   struct {
+			Streams []Livestream `json:"streams"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	if len(respData.Body.Streams) == 0 {
+		return nil, fmt.Errorf("stream %w in response", errNotFound)
+	}
+
+	return &respData.Body.Streams[0], nil
+}
+
+// UploadVideoFile uploads a local file to a presigned URL of the video API and returns the upload ID
+func (c *Client) UploadVideoFile(credentials *VideoCredentials, filePath string) (string, error) {
+	url := fmt.Sprintf("%s/upload", c.baseVideoURL)
+
+	body, err := sendVideoRequest(c, credentials, "POST", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+		Body   struct {
+			Uploads []struct {
+				ID           string `json:"id"`
+				PresignedURL string `json:"presigned_url"`
+			} `json:"uploads"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return "", fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return "", fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	if len(respData.Body.Uploads) == 0 {
+		return "", fmt.Errorf("no upload returned by the video API")
+	}
+	upload := respData.Body.Uploads[0]
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	// the file is streamed to storage directly, so it doesn't go through doRequest and its logging
+	req, err := http.NewRequest("PUT", upload.PresignedURL, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %v", err)
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	log.Printf("DEBUG: Uploading %s (%d bytes) for upload %s", filePath, info.Size(), upload.ID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload video file: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("video file upload error: %s", resp.Status)
+	}
+
+	return upload.ID, nil
+}
+
+func (c *Client) CreateVideo(credentials *VideoCredentials, video VideoRequest) (*Video, error) {
+	url := fmt.Sprintf("%s/video", c.baseVideoURL)
+
+	jsonData, err := json.Marshal(video)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendVideoRequest(c, credentials, "POST", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeVideoResponse(body)
+}
+
+func (c *Client) GetVideo(credentials *VideoCredentials, videoID string) (*Video, error) {
+	url := fmt.Sprintf("%s/video/%s", c.baseVideoURL, videoID)
+
+	body, err := sendVideoRequest(c, credentials, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeVideoResponse(body)
+}
+
+func (c *Client) DeleteVideo(credentials *VideoCredentials, videoID string) error {
+	url := fmt.Sprintf("%s/video/%s", c.baseVideoURL, videoID)
+
+	body, err := sendVideoRequest(c, credentials, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return nil
+}
+
+// WaitForVideoEncoded polls the video API until the video is encoded or encoding failed
+func (c *Client) WaitForVideoEncoded(ctx context.Context, credentials *VideoCredentials, videoID string) (*Video, error) {
+	for {
+		video, err := c.GetVideo(credentials, videoID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch video.State {
+		case videoStateSuccess:
+			return video, nil
+		case videoStateFailed:
+			message := ""
+			if video.Error != nil {
+				message = *video.Error
+			}
+			return video, fmt.Errorf("encoding of video %s failed: %s", videoID, message)
+		}
+		log.Printf("DEBUG: Video %s is %s at %.0f%%, waiting", videoID, video.State, video.Progress)
+
+		select {
+		case <-ctx.Done():
+			return video, fmt.Errorf("timed out waiting for video %s to be encoded: %v", videoID, ctx.Err())
+		case <-time.After(videoPollInterval):
+		}
+	}
+}
+
+// The Theta Video API wraps single videos in a list
+func decodeVideoResponse(body []byte) (*Video, error) {
+	var respData struct {
+		Status string `json:"status"`
+		Body   struct {
+			Videos []Video `json:"videos"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	if len(respData.Body.Videos) == 0 {
+		return nil, fmt.Errorf("video %w in response", errNotFound)
+	}
+
+	return &respData.Body.Videos[0], nil
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type VolumeRequest struct {
+	Name      string `json:"name"`
+	ProjectID string `json:"project_id"`
+	SizeGB    int64  `json:"size_gb"`
+}
+
+// Volume is persistent storage which keeps its data when the deployments mounting it are replaced
+type Volume struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	ProjectID  string `json:"project_id"`
+	SizeGB     int64  `json:"size_gb"`
+	Status     string `json:"status"`
+	CreateTime string `json:"create_time"`
+}
+
+func (c *Client) CreateVolume(volume VolumeRequest) (*Volume, error) {
+	url := fmt.Sprintf("%s/volume", c.baseControllerURL)
+
+	jsonData, err := json.Marshal(volume)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, "POST", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeVolumeResponse(body)
+}
+
+func (c *Client) GetVolume(volumeID, projectID string) (*Volume, error) {
+	url := fmt.Sprintf("%s/volume/%s?project_id=%s", c.baseControllerURL, volumeID, projectID)
+
+	body, err := sendRequest(c, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeVolumeResponse(body)
+}
+
+// UpdateVolume renames or grows the volume, volumes can't shrink
+func (c *Client) UpdateVolume(volumeID string, volume VolumeRequest) (*Volume, error) {
+	url := fmt.Sprintf("%s/volume/%s?project_id=%s", c.baseControllerURL, volumeID, volume.ProjectID)
+
+	jsonData, err := json.Marshal(volume)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRequest(c, "PUT", url, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeVolumeResponse(body)
+}
+
+func (c *Client) DeleteVolume(volumeID, projectID string) error {
+	url := fmt.Sprintf("%s/volume/%s?project_id=%s", c.baseControllerURL, volumeID, projectID)
+
+	body, err := sendRequest(c, "DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+
+	var respData struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return nil
+}
+
+func decodeVolumeResponse(body []byte) (*Volume, error) {
+	var respData struct {
+		Status string `json:"status"`
+		Body   Volume `json:"body"`
+	}
+	if err := json.Unmarshal(body, &respData); err != nil {
+		return nil, fmt.Errorf("error decoding response: %v", err)
+	}
+
+	if respData.Status != "success" {
+		return nil, fmt.Errorf("API response error: %s", respData.Status)
+	}
+
+	return &respData.Body, nil
+}
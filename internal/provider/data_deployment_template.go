@@ -66,6 +66,10 @@ func (d *deploymentTemplateDataSource) Schema(ctx context.Context, req datasourc
 							MarkdownDescription: "The container image of the deployment template",
 							Computed:            true,
 						},
+						"registry_credential_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the registry credential the container images are pulled with",
+							Computed:            true,
+						},
 						"container_port": schema.Int64Attribute{
 							MarkdownDescription: "The container port of the deployment template",
 							Computed:            true,
@@ -79,6 +83,12 @@ func (d *deploymentTemplateDataSource) Schema(ctx context.Context, req datasourc
 							ElementType:         types.StringType,
 							MarkdownDescription: "The environment variables of the deployment template",
 							Computed:            true,
+							Sensitive:           true,
+						},
+						"secret_refs": schema.MapAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "The environment variables of the deployment template set from project secrets",
+							Computed:            true,
 						},
 						"require_env_vars": schema.BoolAttribute{
 							MarkdownDescription: "Whether the deployment template requires environment variables",
@@ -133,20 +143,22 @@ func (d *deploymentTemplateDataSource) Read(ctx context.Context, req datasource.
 	var state struct {
 		ProjectID           types.String `tfsdk:"project_id"`
 		DeploymentTemplates []struct {
-			ID              types.String            `tfsdk:"id"`
-			Name            types.String            `tfsdk:"name"`
-			Description     types.String            `tfsdk:"description"`
-			Tags            []types.String          `tfsdk:"tags"`
-			Category        types.String            `tfsdk:"category"`
-			ProjectID       types.String            `tfsdk:"project_id"`
-			ContainerImages []types.String          `tfsdk:"container_images"`
-			ContainerPort   types.Int64             `tfsdk:"container_port"`
-			ContainerArgs   []types.String          `tfsdk:"container_args"`
-			EnvVars         map[string]types.String `tfsdk:"env_vars"`
-			RequireEnvVars  types.Bool              `tfsdk:"require_env_vars"`
-			Rank            types.Int64             `tfsdk:"rank"`
-			IconURL         types.String            `tfsdk:"icon_url"`
-			CreateTime      types.String            `tfsdk:"create_time"`
+			ID                   types.String            `tfsdk:"id"`
+			Name                 types.String            `tfsdk:"name"`
+			Description          types.String            `tfsdk:"description"`
+			Tags                 []types.String          `tfsdk:"tags"`
+			Category             types.String            `tfsdk:"category"`
+			ProjectID            types.String            `tfsdk:"project_id"`
+			ContainerImages      []types.String          `tfsdk:"container_images"`
+			RegistryCredentialID types.String            `tfsdk:"registry_credential_id"`
+			ContainerPort        types.Int64             `tfsdk:"container_port"`
+			ContainerArgs        []types.String          `tfsdk:"container_args"`
+			EnvVars              map[string]types.String `tfsdk:"env_vars"`
+			SecretRefs           map[string]types.String `tfsdk:"secret_refs"`
+			RequireEnvVars       types.Bool              `tfsdk:"require_env_vars"`
+			Rank                 types.Int64             `tfsdk:"rank"`
+			IconURL              types.String            `tfsdk:"icon_url"`
+			CreateTime           types.String            `tfsdk:"create_time"`
 		} `tfsdk:"deployment_templates"`
 	}
 
@@ -171,6 +183,11 @@ func (d *deploymentTemplateDataSource) Read(ctx context.Context, req datasource.
 			envVars[k] = types.StringValue(v)
 		}
 
+		secretRefs := make(map[string]types.String)
+		for k, v := range template.SecretRefs {
+			secretRefs[k] = types.StringValue(v)
+		}
+
 		tags := make([]types.String, len(template.Tags))
 		for i, tag := range template.Tags {
 			tags[i] = types.StringValue(tag)
@@ -201,35 +218,39 @@ func (d *deploymentTemplateDataSource) Read(ctx context.Context, req datasource.
 		}
 
 		state.DeploymentTemplates = append(state.DeploymentTemplates, struct {
-			ID              types.String            `tfsdk:"id"`
-			Name            types.String            `tfsdk:"name"`
-			Description     types.String            `tfsdk:"description"`
-			Tags            []types.String          `tfsdk:"tags"`
-			Category        types.String            `tfsdk:"category"`
-			ProjectID       types.String            `tfsdk:"project_id"`
-			ContainerImages []types.String          `tfsdk:"container_images"`
-			ContainerPort   types.Int64             `tfsdk:"container_port"`
-			ContainerArgs   []types.String          `tfsdk:"container_args"`
-			EnvVars         map[string]types.String `tfsdk:"env_vars"`
-			RequireEnvVars  types.Bool              `tfsdk:"require_env_vars"`
-			Rank            types.Int64             `tfsdk:"rank"`
-			IconURL         types.String            `tfsdk:"icon_url"`
-			CreateTime      types.String            `tfsdk:"create_time"`
+			ID                   types.String            `tfsdk:"id"`
+			Name                 types.String            `tfsdk:"name"`
+			Description          types.String            `tfsdk:"description"`
+			Tags                 []types.String          `tfsdk:"tags"`
+			Category             types.String            `tfsdk:"category"`
+			ProjectID            types.String            `tfsdk:"project_id"`
+			ContainerImages      []types.String          `tfsdk:"container_images"`
+			RegistryCredentialID types.String            `tfsdk:"registry_credential_id"`
+			ContainerPort        types.Int64             `tfsdk:"container_port"`
+			ContainerArgs        []types.String          `tfsdk:"container_args"`
+			EnvVars              map[string]types.String `tfsdk:"env_vars"`
+			SecretRefs           map[string]types.String `tfsdk:"secret_refs"`
+			RequireEnvVars       types.Bool              `tfsdk:"require_env_vars"`
+			Rank                 types.Int64             `tfsdk:"rank"`
+			IconURL              types.String            `tfsdk:"icon_url"`
+			CreateTime           types.String            `tfsdk:"create_time"`
 		}{
-			ID:              types.StringValue(template.ID),
-			Name:            types.StringValue(template.Name),
-			Description:     types.StringValue(template.Description),
-			Tags:            tags,
-			Category:        types.StringValue(template.Category),
-			ProjectID:       types.StringValue(template.ProjectID),
-			ContainerImages: containerImages,
-			ContainerPort:   types.Int64Value(template.ContainerPort),
-			ContainerArgs:   containerArgs,
-			EnvVars:         envVars,
-			RequireEnvVars:  requireEnvVars,
-			Rank:            rank,
-			IconURL:         types.StringValue(template.IconURL),
-			CreateTime:      types.StringValue(template.CreateTime.Format(time.RFC3339)),
+			ID:                   types.StringValue(template.ID),
+			Name:                 types.StringValue(template.Name),
+			Description:          types.StringValue(template.Description),
+			Tags:                 tags,
+			Category:             types.StringValue(template.Category),
+			ProjectID:            types.StringValue(template.ProjectID),
+			ContainerImages:      containerImages,
+			RegistryCredentialID: stringValueOrNull(template.RegistryCredentialID, types.StringNull()),
+			ContainerPort:        types.Int64Value(template.ContainerPort),
+			ContainerArgs:        containerArgs,
+			EnvVars:              envVars,
+			SecretRefs:           secretRefs,
+			RequireEnvVars:       requireEnvVars,
+			Rank:                 rank,
+			IconURL:              types.StringValue(template.IconURL),
+			CreateTime:           types.StringValue(template.CreateTime.Format(time.RFC3339)),
 		})
 	}
 
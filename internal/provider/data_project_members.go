@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DataSource for project members
+type projectMembersDataSource struct {
+	client *Client
+}
+
+func ProjectMembersDataSource() datasource.DataSource {
+	return &projectMembersDataSource{}
+}
+
+func (d *projectMembersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "theta_project_members"
+}
+
+func (d *projectMembersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Data source for fetching the members of a Theta project",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project",
+				Required:            true,
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "List of project members",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the user",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "The email address of the user",
+							Computed:            true,
+						},
+						"first_name": schema.StringAttribute{
+							MarkdownDescription: "The first name of the user",
+							Computed:            true,
+						},
+						"last_name": schema.StringAttribute{
+							MarkdownDescription: "The last name of the user",
+							Computed:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: "The role of the user in the project",
+							Computed:            true,
+						},
+						"join_time": schema.StringAttribute{
+							MarkdownDescription: "When the user joined the project",
+							Computed:            true,
+						},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *projectMembersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	log.Println("Data source Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", "Expected *Client")
+		log.Println("Unexpected Data Source Configure Type")
+		return
+	}
+
+	d.client = client
+	log.Println("Client configured in data source")
+}
+
+func (d *projectMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Client Error", "The client is not configured")
+		log.Println("Client is not configured in Read method")
+		return
+	}
+
+	var state struct {
+		ProjectID types.String `tfsdk:"project_id"`
+		Members   []struct {
+			UserID    types.String `tfsdk:"user_id"`
+			Email     types.String `tfsdk:"email"`
+			FirstName types.String `tfsdk:"first_name"`
+			LastName  types.String `tfsdk:"last_name"`
+			Role      types.String `tfsdk:"role"`
+			JoinTime  types.String `tfsdk:"join_time"`
+		} `tfsdk:"members"`
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := d.client.GetProjectMembers(state.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project members, got error: %s", err))
+		return
+	}
+
+	for _, member := range members {
+		state.Members = append(state.Members, struct {
+			UserID    types.String `tfsdk:"user_id"`
+			Email     types.String `tfsdk:"email"`
+			FirstName types.String `tfsdk:"first_name"`
+			LastName  types.String `tfsdk:"last_name"`
+			Role      types.String `tfsdk:"role"`
+			JoinTime  types.String `tfsdk:"join_time"`
+		}{
+			UserID:    types.StringValue(member.UserID),
+			Email:     types.StringValue(member.Email),
+			FirstName: types.StringValue(member.FirstName),
+			LastName:  stringPtrToValue(member.LastName),
+			Role:      types.StringValue(member.Role),
+			JoinTime:  types.StringValue(member.JoinTime),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Function returning the ID of a deployment from its URL
+type deploymentIDFromURLFunction struct{}
+
+var _ function.Function = &deploymentIDFromURLFunction{}
+
+func DeploymentIDFromURLFunction() function.Function {
+	return &deploymentIDFromURLFunction{}
+}
+
+func (f *deploymentIDFromURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "deployment_id_from_url"
+}
+
+func (f *deploymentIDFromURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the ID of a deployment",
+		MarkdownDescription: "Returns the ID of a deployment from its URL, which has the format `https://{name}-{id}.{domain}`",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "The URL of the deployment, the scheme is optional",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *deploymentIDFromURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var url types.String
+	resp.Error = req.Arguments.Get(ctx, &url)
+	if resp.Error != nil {
+		return
+	}
+
+	if url.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, types.StringUnknown())
+		return
+	}
+
+	id, err := extractDeploymentID(url.ValueString())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, id)
+}
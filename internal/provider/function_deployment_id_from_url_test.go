@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDeploymentIDFromURLFunction(t *testing.T) {
+	tests := map[string]struct {
+		url     tftypes.Value
+		want    tftypes.Value
+		wantErr bool
+	}{
+		"valid": {
+			url:  tftypes.NewValue(tftypes.String, "https://vllm-abc123.tec-s1.onthetaedgecloud.com"),
+			want: tftypes.NewValue(tftypes.String, "abc123"),
+		},
+		"name with hyphens": {
+			url:  tftypes.NewValue(tftypes.String, "https://my-llama-server-abc123.tec-s1.onthetaedgecloud.com/v1"),
+			want: tftypes.NewValue(tftypes.String, "abc123"),
+		},
+		"without scheme": {
+			url:  tftypes.NewValue(tftypes.String, "vllm-abc123.tec-s1.onthetaedgecloud.com"),
+			want: tftypes.NewValue(tftypes.String, "abc123"),
+		},
+		"without id": {
+			url:     tftypes.NewValue(tftypes.String, "https://vllm.tec-s1.onthetaedgecloud.com"),
+			wantErr: true,
+		},
+		"empty": {
+			url:     tftypes.NewValue(tftypes.String, ""),
+			wantErr: true,
+		},
+		"null": {
+			url:     tftypes.NewValue(tftypes.String, nil),
+			wantErr: true,
+		},
+		"unknown": {
+			url:  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			want: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := callFunction(t, "deployment_id_from_url", tt.url)
+			if tt.wantErr {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if got := functionResult(t, resp, tftypes.String); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
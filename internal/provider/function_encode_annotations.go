@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Function encoding tags and a nickname into deployment annotations the way the console does
+type encodeAnnotationsFunction struct{}
+
+var _ function.Function = &encodeAnnotationsFunction{}
+
+func EncodeAnnotationsFunction() function.Function {
+	return &encodeAnnotationsFunction{}
+}
+
+func (f *encodeAnnotationsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "encode_annotations"
+}
+
+func (f *encodeAnnotationsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encodes tags and a nickname into deployment annotations",
+		MarkdownDescription: "Returns the annotations with the tags encoded as the JSON list the console expects and the nickname added, " +
+			"as sent to the controller by `theta_deployment`",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "annotations",
+				MarkdownDescription: "Other annotations to include, may be null",
+				ElementType:         types.StringType,
+				AllowNullValue:      true,
+			},
+			function.SetParameter{
+				Name:                "tags",
+				MarkdownDescription: fmt.Sprintf("The tags, any of: %s. May be null", strings.Join(allowedTags, ", ")),
+				ElementType:         types.StringType,
+				AllowNullValue:      true,
+			},
+			function.StringParameter{
+				Name:                "nickname",
+				MarkdownDescription: "The nickname, may be null",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *encodeAnnotationsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var annotations types.Map
+	var tagsValue types.Set
+	var nicknameValue types.String
+	resp.Error = req.Arguments.Get(ctx, &annotations, &tagsValue, &nicknameValue)
+	if resp.Error != nil {
+		return
+	}
+
+	if annotations.IsUnknown() || tagsValue.IsUnknown() || nicknameValue.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, types.MapUnknown(types.StringType))
+		return
+	}
+
+	var tags []string
+	if !tagsValue.IsNull() {
+		tags = stringSliceFromSetValue(tagsValue)
+	}
+	for _, tag := range tags {
+		if !containsString(allowedTags, tag) {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Tag %q is not allowed, expected one of: %s", tag, strings.Join(allowedTags, ", ")))
+			return
+		}
+	}
+
+	encoded, err := encodeDeploymentAnnotations(stringMapFromValue(annotations), tags, nicknameValue.ValueStringPointer())
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, encoded)
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEncodeAnnotationsFunction(t *testing.T) {
+	annotationsType := tftypes.Map{ElementType: tftypes.String}
+	tagsType := tftypes.Set{ElementType: tftypes.String}
+	annotations := func(values map[string]string) tftypes.Value {
+		elements := make(map[string]tftypes.Value, len(values))
+		for k, v := range values {
+			elements[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(annotationsType, elements)
+	}
+	tags := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, len(values))
+		for i, v := range values {
+			elements[i] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(tagsType, elements)
+	}
+
+	tests := map[string]struct {
+		annotations tftypes.Value
+		tags        tftypes.Value
+		nickname    tftypes.Value
+		want        tftypes.Value
+		wantErr     bool
+	}{
+		"tags and nickname": {
+			annotations: annotations(map[string]string{"team": "ml"}),
+			tags:        tags("LLM", "ImageGen"),
+			nickname:    tftypes.NewValue(tftypes.String, "llama"),
+			want: annotations(map[string]string{
+				"team":     "ml",
+				"tags":     `["ImageGen","LLM"]`,
+				"nickname": "llama",
+			}),
+		},
+		"null arguments": {
+			annotations: tftypes.NewValue(annotationsType, nil),
+			tags:        tftypes.NewValue(tagsType, nil),
+			nickname:    tftypes.NewValue(tftypes.String, nil),
+			want:        annotations(map[string]string{}),
+		},
+		"empty tags": {
+			annotations: tftypes.NewValue(annotationsType, nil),
+			tags:        tags(),
+			nickname:    tftypes.NewValue(tftypes.String, nil),
+			want:        annotations(map[string]string{"tags": "[]"}),
+		},
+		"tag not allowed": {
+			annotations: tftypes.NewValue(annotationsType, nil),
+			tags:        tags("Unknown"),
+			nickname:    tftypes.NewValue(tftypes.String, nil),
+			wantErr:     true,
+		},
+		"unknown nickname": {
+			annotations: tftypes.NewValue(annotationsType, nil),
+			tags:        tags("LLM"),
+			nickname:    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			want:        tftypes.NewValue(annotationsType, tftypes.UnknownValue),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := callFunction(t, "encode_annotations", tt.annotations, tt.tags, tt.nickname)
+			if tt.wantErr {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if got := functionResult(t, resp, annotationsType); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Path of the OpenAI compatible API served by vLLM and similar inference servers
+const openAIAPIPath = "/v1"
+
+// Function returning the base URL of the OpenAI compatible API of a deployment
+type openAIBaseURLFunction struct{}
+
+var _ function.Function = &openAIBaseURLFunction{}
+
+func OpenAIBaseURLFunction() function.Function {
+	return &openAIBaseURLFunction{}
+}
+
+func (f *openAIBaseURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "openai_base_url"
+}
+
+func (f *openAIBaseURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the OpenAI compatible API base URL of a deployment",
+		MarkdownDescription: "Returns the base URL of the OpenAI compatible API of a deployment, such as one running vLLM, " +
+			"to be used as the `base_url` of OpenAI clients",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "The URL of the deployment, the scheme is optional",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *openAIBaseURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var url types.String
+	resp.Error = req.Arguments.Get(ctx, &url)
+	if resp.Error != nil {
+		return
+	}
+
+	if url.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, types.StringUnknown())
+		return
+	}
+
+	_, _, host, err := parseDeploymentURL(url.ValueString())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, fmt.Sprintf("https://%s%s", host, openAIAPIPath))
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOpenAIBaseURLFunction(t *testing.T) {
+	tests := map[string]struct {
+		url     tftypes.Value
+		want    tftypes.Value
+		wantErr bool
+	}{
+		"valid": {
+			url:  tftypes.NewValue(tftypes.String, "https://vllm-abc123.tec-s1.onthetaedgecloud.com"),
+			want: tftypes.NewValue(tftypes.String, "https://vllm-abc123.tec-s1.onthetaedgecloud.com/v1"),
+		},
+		"path and port are dropped": {
+			url:  tftypes.NewValue(tftypes.String, "https://vllm-abc123.tec-s1.onthetaedgecloud.com:443/docs"),
+			want: tftypes.NewValue(tftypes.String, "https://vllm-abc123.tec-s1.onthetaedgecloud.com/v1"),
+		},
+		"without scheme": {
+			url:  tftypes.NewValue(tftypes.String, "vllm-abc123.tec-s1.onthetaedgecloud.com"),
+			want: tftypes.NewValue(tftypes.String, "https://vllm-abc123.tec-s1.onthetaedgecloud.com/v1"),
+		},
+		"malformed": {
+			url:     tftypes.NewValue(tftypes.String, "https://localhost"),
+			wantErr: true,
+		},
+		"null": {
+			url:     tftypes.NewValue(tftypes.String, nil),
+			wantErr: true,
+		},
+		"unknown": {
+			url:  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			want: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := callFunction(t, "openai_base_url", tt.url)
+			if tt.wantErr {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if got := functionResult(t, resp, tftypes.String); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
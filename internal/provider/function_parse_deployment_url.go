@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var deploymentURLAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"id":   types.StringType,
+	"host": types.StringType,
+}
+
+// Function splitting a deployment URL into its parts
+type parseDeploymentURLFunction struct{}
+
+var _ function.Function = &parseDeploymentURLFunction{}
+
+func ParseDeploymentURLFunction() function.Function {
+	return &parseDeploymentURLFunction{}
+}
+
+func (f *parseDeploymentURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_deployment_url"
+}
+
+func (f *parseDeploymentURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Splits a deployment URL into its parts",
+		MarkdownDescription: "Splits a deployment URL of the format `https://{name}-{id}.{domain}` into an object with the `name` and `id` of the deployment and its `host`",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "The URL of the deployment, the scheme is optional",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: deploymentURLAttrTypes,
+		},
+	}
+}
+
+func (f *parseDeploymentURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var url types.String
+	resp.Error = req.Arguments.Get(ctx, &url)
+	if resp.Error != nil {
+		return
+	}
+
+	if url.IsUnknown() {
+		resp.Error = resp.Result.Set(ctx, types.ObjectUnknown(deploymentURLAttrTypes))
+		return
+	}
+
+	name, id, host, err := parseDeploymentURL(url.ValueString())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result := types.ObjectValueMust(deploymentURLAttrTypes, map[string]attr.Value{
+		"name": types.StringValue(name),
+		"id":   types.StringValue(id),
+		"host": types.StringValue(host),
+	})
+	resp.Error = resp.Result.Set(ctx, result)
+}
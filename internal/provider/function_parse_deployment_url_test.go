@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseDeploymentURLFunction(t *testing.T) {
+	resultType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name": tftypes.String,
+		"id":   tftypes.String,
+		"host": tftypes.String,
+	}}
+	deploymentURL := func(name, id, host string) tftypes.Value {
+		return tftypes.NewValue(resultType, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, name),
+			"id":   tftypes.NewValue(tftypes.String, id),
+			"host": tftypes.NewValue(tftypes.String, host),
+		})
+	}
+
+	tests := map[string]struct {
+		url     tftypes.Value
+		want    tftypes.Value
+		wantErr bool
+	}{
+		"valid": {
+			url:  tftypes.NewValue(tftypes.String, "https://vllm-abc123.tec-s1.onthetaedgecloud.com"),
+			want: deploymentURL("vllm", "abc123", "vllm-abc123.tec-s1.onthetaedgecloud.com"),
+		},
+		"name with hyphens": {
+			url:  tftypes.NewValue(tftypes.String, "https://my-llama-server-abc123.tec-s1.onthetaedgecloud.com/v1"),
+			want: deploymentURL("my-llama-server", "abc123", "my-llama-server-abc123.tec-s1.onthetaedgecloud.com"),
+		},
+		"malformed": {
+			url:     tftypes.NewValue(tftypes.String, "https://-abc123.tec-s1.onthetaedgecloud.com"),
+			wantErr: true,
+		},
+		"null": {
+			url:     tftypes.NewValue(tftypes.String, nil),
+			wantErr: true,
+		},
+		"unknown": {
+			url:  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			want: tftypes.NewValue(resultType, tftypes.UnknownValue),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := callFunction(t, "parse_deployment_url", tt.url)
+			if tt.wantErr {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if got := functionResult(t, resp, resultType); !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
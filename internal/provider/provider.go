@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -14,6 +15,8 @@ type ThetaProvider struct {
 	client *Client
 }
 
+var _ provider.ProviderWithFunctions = &ThetaProvider{}
+
 func New() provider.Provider {
 	return &ThetaProvider{}
 }
@@ -62,7 +65,7 @@ func (p *ThetaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	log.Println("Client successfully created with authToken:", client.authToken)
+	log.Println("Client successfully created")
 	p.client = client
 
 	resp.ResourceData = client
@@ -73,6 +76,26 @@ func (p *ThetaProvider) Resources(ctx context.Context) []func() resource.Resourc
 	return []func() resource.Resource{
 		DeploymentResource,
 		DeploymentTemplateResource,
+		DeploymentScheduleResource,
+		OrganizationResource,
+		ProjectMemberResource,
+		ProjectGatewayResource,
+		ProjectSecretResource,
+		VideoLivestreamResource,
+		VideoResource,
+		VolumeResource,
+		RegistryCredentialResource,
+		OrganizationMemberResource,
+		OrganizationInvitationResource,
+	}
+}
+
+func (p *ThetaProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		DeploymentIDFromURLFunction,
+		ParseDeploymentURLFunction,
+		EncodeAnnotationsFunction,
+		OpenAIBaseURLFunction,
 	}
 }
 
@@ -81,5 +104,6 @@ func (p *ThetaProvider) DataSources(ctx context.Context) []func() datasource.Dat
 		OrganizationDataSource,
 		ProjectDataSource,
 		DeploymentTemplateDataSource,
+		ProjectMembersDataSource,
 	}
 }
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// callFunction calls a provider function through the protocol server, the way Terraform does,
+// so null and unknown arguments are handled by the framework before the function runs
+func callFunction(t *testing.T, name string, args ...tftypes.Value) *tfprotov6.CallFunctionResponse {
+	t.Helper()
+
+	server, err := providerserver.NewProtocol6WithError(New())()
+	if err != nil {
+		t.Fatalf("unable to create provider server: %s", err)
+	}
+
+	arguments := make([]*tfprotov6.DynamicValue, len(args))
+	for i, arg := range args {
+		value, err := tfprotov6.NewDynamicValue(arg.Type(), arg)
+		if err != nil {
+			t.Fatalf("unable to encode argument %d: %s", i, err)
+		}
+		arguments[i] = &value
+	}
+
+	resp, err := server.CallFunction(context.Background(), &tfprotov6.CallFunctionRequest{
+		Name:      name,
+		Arguments: arguments,
+	})
+	if err != nil {
+		t.Fatalf("unable to call function %s: %s", name, err)
+	}
+
+	return resp
+}
+
+// functionResult decodes the result of a successful function call
+func functionResult(t *testing.T, resp *tfprotov6.CallFunctionResponse, typ tftypes.Type) tftypes.Value {
+	t.Helper()
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected function error: %s", resp.Error.Text)
+	}
+
+	value, err := resp.Result.Unmarshal(typ)
+	if err != nil {
+		t.Fatalf("unable to decode function result: %s", err)
+	}
+
+	return value
+}
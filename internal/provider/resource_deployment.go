@@ -2,19 +2,42 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+const (
+	defaultDeploymentCreateTimeout = 30 * time.Minute
+	defaultDeploymentUpdateTimeout = 30 * time.Minute
+	defaultDeploymentDeleteTimeout = 10 * time.Minute
+)
+
 // Resource for deployment
 type deploymentResource struct {
 	client *Client
 }
 
+var _ resource.ResourceWithValidateConfig = &deploymentResource{}
+
 func DeploymentResource() resource.Resource {
 	return &deploymentResource{}
 }
@@ -25,42 +48,221 @@ func (r *deploymentResource) Metadata(ctx context.Context, req resource.Metadata
 func (r *deploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Resource for managing Theta deployments",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the deployment",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessReplaced{},
+				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the deployment",
+				MarkdownDescription: "The name of the deployment. Lowercase letters, digits and hyphens only, since it becomes part of the deployment URL",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessCreateBeforeDestroy(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, maxDeploymentNameLength),
+					stringvalidator.RegexMatches(deploymentNameRegexp, "must contain only lowercase letters, digits and hyphens, and start and end with a letter or digit"),
+				},
 			},
 			"project_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the project",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessCreateBeforeDestroy(),
+				},
+			},
+			"deployment_template_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of a custom or standard deployment template. `deployment_image_id`, `container_image` " +
+					"and `container_port` default to the values of the template when omitted",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessCreateBeforeDestroy(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"deployment_image_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the deployment image",
-				Required:            true,
+				MarkdownDescription: "The ID of the deployment image. Defaults to `deployment_template_id`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					requiresReplaceUnlessCreateBeforeDestroy(),
+				},
 			},
 			"container_image": schema.StringAttribute{
-				MarkdownDescription: "The container image",
-				Required:            true,
+				MarkdownDescription: "The container image. Defaults to the first container image of the template, " +
+					"and must be one of the template's container images when `deployment_template_id` is set",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					requiresReplaceUnlessCreateBeforeDestroy(),
+				},
+			},
+			"registry_credential_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of a `theta_registry_credential` to pull a private container image with. Defaults to the credential of the template",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"container_port": schema.Int64Attribute{
+				MarkdownDescription: "The port the container listens on. Defaults to the container port of the template",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"container_args": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arguments passed to the container, replacing the container arguments of the template",
+				Optional:            true,
+			},
+			"env_vars": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Environment variables merged over the environment variables of the template",
+				Optional:            true,
+			},
+			"secret_env_vars": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Environment variables holding secrets such as API tokens, merged over the environment variables of the template like `env_vars`, but never shown in plan output",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"secret_refs": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Environment variables set from project secrets, mapping the variable name to the name of a " +
+					"`theta_project_secret`, merged over the variables and secret references of the template like `env_vars`",
+				Optional: true,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(secretNameRegexp, "must be the name of a project secret")),
+				},
+			},
+			"volume_mounts": schema.ListNestedAttribute{
+				MarkdownDescription: "Volumes mounted into the containers of the deployment, their data survives replacements of the deployment",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"volume_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of a `theta_volume` in the same project",
+							Required:            true,
+						},
+						"mount_path": schema.StringAttribute{
+							MarkdownDescription: "The absolute path the volume is mounted at",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(mountPathRegexp, "must be an absolute path"),
+							},
+						},
+						"read_only": schema.BoolAttribute{
+							MarkdownDescription: "Whether the volume is mounted read-only. Defaults to `false`",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+					},
+				},
 			},
 			"min_replicas": schema.Int64Attribute{
 				MarkdownDescription: "Minimum number of replicas",
 				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"max_replicas": schema.Int64Attribute{
-				MarkdownDescription: "Maximum number of replicas",
+				MarkdownDescription: "Maximum number of replicas, must not be lower than `min_replicas`",
 				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"autoscaling": schema.SingleNestedAttribute{
+				MarkdownDescription: "When the controller scales the deployment between `min_replicas` and `max_replicas`. " +
+					"Without it the controller's default scaling applies",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"metric": schema.StringAttribute{
+						MarkdownDescription: "The metric to scale on, `concurrent_requests` per replica or `gpu_utilization` in percent",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(autoscalingMetricConcurrentRequests, autoscalingMetricGPUUtilization),
+						},
+					},
+					"target": schema.Int64Attribute{
+						MarkdownDescription: "The value of the metric the controller keeps each replica at, at most 100 for `gpu_utilization`",
+						Required:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"scale_up_cooldown_seconds": schema.Int64Attribute{
+						MarkdownDescription: "How long the controller waits after scaling before it adds replicas again",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"scale_down_cooldown_seconds": schema.Int64Attribute{
+						MarkdownDescription: "How long the controller waits after scaling before it removes replicas again",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"scale_to_zero_idle_timeout_seconds": schema.Int64Attribute{
+						MarkdownDescription: "How long the deployment has to be idle before it's scaled to zero replicas. Required when `min_replicas` is 0",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the deployment runs. Setting it to `false` pauses the deployment without deleting it, " +
+					"so it keeps its ID and `deployment_url` when it's enabled again. Defaults to `true`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
 			},
 			"vm_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the VM",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessCreateBeforeDestroy(),
+				},
 			},
 			"annotations": schema.MapAttribute{
 				ElementType:         types.StringType,
-				MarkdownDescription: "Annotations for the deployment",
+				MarkdownDescription: "Annotations for the deployment. JSON encoded values must be valid JSON, `tags` and `nickname` are set through their own attributes",
+				Optional:            true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+					annotationsValidator{},
+				},
+			},
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: fmt.Sprintf("Tags shown for the deployment in the console, any of: %s", strings.Join(allowedTags, ", ")),
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(allowedTags...)),
+				},
+			},
+			"nickname": schema.StringAttribute{
+				MarkdownDescription: "The nickname shown for the deployment in the console",
 				Optional:            true,
 			},
 			"auth_username": schema.StringAttribute{
@@ -68,14 +270,137 @@ func (r *deploymentResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Required:            true,
 			},
 			"auth_password": schema.StringAttribute{
-				MarkdownDescription: "The authentication password",
-				Required:            true,
+				MarkdownDescription: "The authentication password. It's write-only, so it never reaches the state, which only keeps its argon2id hash. " +
+					"**Setting it requires Terraform 1.11 or later**, earlier versions reject configurations which set it. " +
+					"When omitted the provider generates a strong password and exposes it once through `generated_auth_password`",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"auth_password_version": schema.Int64Attribute{
+				MarkdownDescription: "Change this value to generate a new password when `auth_password` is omitted. " +
+					"Removing `auth_password` from the config keeps the current password until this value changes",
+				Optional: true,
+			},
+			"auth_password_hash": schema.StringAttribute{
+				MarkdownDescription: "argon2id hash of the authentication password, used to detect password changes in the config and outside of Terraform",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					authPasswordHashPlanModifier{},
+				},
+			},
+			"generated_auth_password": schema.StringAttribute{
+				MarkdownDescription: "The password generated by the provider when `auth_password` is omitted. " +
+					"Only set by the apply which generated it and cleared on the next refresh, so capture it right away",
+				Computed:  true,
+				Sensitive: true,
 			},
 			"deployment_url": schema.StringAttribute{
 				MarkdownDescription: "URL used to access successfull deployment",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessReplaced{},
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the deployment reported by the controller",
+				Computed:            true,
+			},
+			"running_replicas": schema.Int64Attribute{
+				MarkdownDescription: "The number of replicas which are up and serving",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "When the deployment was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessReplaced{},
+				},
+			},
+			"machine_type_details": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Details of the machine type the deployment runs on, such as its CPU, memory and GPU",
+				Computed:            true,
+			},
+			"replicas": schema.ListNestedAttribute{
+				MarkdownDescription: "The replicas of the deployment",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the replica",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the replica",
+							Computed:            true,
+						},
+						"ready": schema.BoolAttribute{
+							MarkdownDescription: "Whether the replica is ready to serve requests",
+							Computed:            true,
+						},
+						"restart_count": schema.Int64Attribute{
+							MarkdownDescription: "How often the container of the replica was restarted",
+							Computed:            true,
+						},
+						"started_at": schema.StringAttribute{
+							MarkdownDescription: "When the replica was started",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the deployment to have running replicas after create and update, and to be gone after delete. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"replacement_strategy": schema.StringAttribute{
+				MarkdownDescription: "How the deployment is replaced when an attribute that can't be updated in place changes. " +
+					"`destroy_before_create` (default) deletes the existing deployment first. `create_before_destroy` creates the new deployment, " +
+					"waits for it to become ready and only then deletes the existing one, keeping the existing deployment if the new one never becomes ready",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(replacementStrategyDestroyBeforeCreate),
+				Validators: []validator.String{
+					stringvalidator.OneOf(replacementStrategyDestroyBeforeCreate, replacementStrategyCreateBeforeDestroy),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *deploymentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateSecretEnvVarKeys(ctx, req.Config)...)
+
+	var minReplicas, maxReplicas types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("min_replicas"), &minReplicas)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("max_replicas"), &maxReplicas)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateDeploymentAutoscaling(ctx, req.Config, minReplicas, maxReplicas)...)
+
+	// values coming from other resources are only known at apply time
+	if minReplicas.IsNull() || minReplicas.IsUnknown() || maxReplicas.IsNull() || maxReplicas.IsUnknown() {
+		return
+	}
+
+	if minReplicas.ValueInt64() > maxReplicas.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("min_replicas"),
+			"Invalid Replica Bounds",
+			fmt.Sprintf("min_replicas (%d) must not be greater than max_replicas (%d)", minReplicas.ValueInt64(), maxReplicas.ValueInt64()),
+		)
 	}
 }
 
@@ -105,7 +430,34 @@ func (r *deploymentResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultDeploymentCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.resolveUnknownTemplateValues(ctx, &plan, req.Config)...)
+	// auth_password is write-only, so it's only in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_password"), &plan.AuthPassword)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, generated, err := newDeploymentPassword(plan.AuthPassword)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating deployment", err.Error())
+		return
+	}
+
 	nativePlan := convertDeploymentToNativePlan(plan)
+	nativePlan.AuthPassword = password
+	if err := r.mergeTemplateOverrides(plan, &nativePlan, false); err != nil {
+		resp.Diagnostics.AddError("Error creating deployment", "Could not read deployment template, unexpected error: "+err.Error())
+		return
+	}
 
 	// Call Client's CreateDeployment method
 	deployment, err := r.client.CreateDeployment(nativePlan)
@@ -117,8 +469,35 @@ func (r *deploymentResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	state := convertToDeploymentTerraformState(deployment)
+	state := convertToDeploymentTerraformState(deployment, DeploymentTerraformState(plan))
+	state.WaitForReady = plan.WaitForReady
+	state.ReplacementStrategy = plan.ReplacementStrategy
+	state.Timeouts = plan.Timeouts
+	state.AuthPasswordVersion = plan.AuthPasswordVersion
+	if err := setDeploymentPasswordState(&state, password, generated); err != nil {
+		resp.Diagnostics.AddError("Error creating deployment", err.Error())
+		return
+	}
+
+	// The deployment exists at this point, so it's saved to state even if it never becomes
+	// ready, which lets Terraform taint it instead of losing track of it
+	r.refreshDeploymentStatus(&state)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a paused deployment never gets running replicas
+	if plan.WaitForReady.ValueBool() && plan.Enabled.ValueBool() {
+		ready, err := r.client.WaitForDeploymentReady(ctx, deployment.ID, deployment.ProjectID)
+		if ready != nil {
+			setDeploymentStatusState(&state, ready)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Deployment Not Ready", fmt.Sprintf("Deployment %s was created but did not become ready: %s", deployment.ID, err))
+		}
+	}
 }
 
 func (r *deploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -134,7 +513,7 @@ func (r *deploymentResource) Read(ctx context.Context, req resource.ReadRequest,
 	// Call Client's GetDeploymentByID method
 	deployment, err := r.client.GetDeploymentByID(state.ID.ValueString(), state.ProjectID.ValueString())
 	if err != nil {
-		if err.Error() == "Deployment not found" {
+		if errors.Is(err, errNotFound) {
 			resp.State.RemoveResource(ctx)
 			resp.Diagnostics.AddWarning("Deployment Not Found", "The deployment with the specified ID was not found.")
 		} else {
@@ -155,13 +534,31 @@ func (r *deploymentResource) Read(ctx context.Context, req resource.ReadRequest,
 	// this is OK because we don't care what the endpoint returns
 	// we want to compare what the .tf config file contains vs. the current .tfstate
 	deployment.DeploymentImageID = state.DeploymentImageID.ValueString()
+	// same for the port of deployments listed without one
+	if deployment.ContainerPort == 0 {
+		deployment.ContainerPort = state.ContainerPort.ValueInt64()
+	}
+	// and the minimum replicas, which may be 0 for deployments scaling to zero
+	if deployment.MinReplicas == replicasNotReported {
+		deployment.MinReplicas = state.MinReplicas.ValueInt64()
+	}
+	// a schedule moves the bounds on its own, which isn't drift
+	if err := keepScheduledReplicaBounds(r.client, deployment, state); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployment schedule, got error: %s", err))
+		return
+	}
 
 	// Convert the deployment to Terraform state
-	newState := convertToDeploymentTerraformState(deployment)
-
-	// Log state comparison for debugging
-	log.Printf("State Before: %+v", state)
-	log.Printf("New State From API: %+v", newState)
+	newState := convertToDeploymentTerraformState(deployment, state)
+	newState.WaitForReady = state.WaitForReady
+	newState.ReplacementStrategy = state.ReplacementStrategy
+	newState.Timeouts = state.Timeouts
+
+	// The controller returns the password in plain text, it's only compared against the stored hash
+	readDeploymentPasswordState(&newState, state, deployment.AuthPassword)
+	if newState.AuthPasswordHash.IsNull() {
+		resp.Diagnostics.AddWarning("Deployment Password Changed", "The deployment password was changed outside of Terraform and will be reset on the next apply.")
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
@@ -178,27 +575,84 @@ func (r *deploymentResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	// Delete the existing resource
-	_, err := r.client.DeleteDeployment(state.ID.ValueString(), state.ProjectID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete deployment, got error: %s", err))
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultDeploymentUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Create the new resource
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.resolveUnknownTemplateValues(ctx, &plan, req.Config)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_password"), &plan.AuthPassword)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Immutable attributes only reach Update when the provider handles the replacement itself
+	if deploymentRequiresReplacement(plan, state) {
+		r.replaceDeployment(ctx, plan, state, resp)
+		return
+	}
+
+	// Everything else can be sent to the controller as-is
 	nativePlan := convertDeploymentToNativePlan(plan)
-	deployment, err := r.client.CreateDeployment(nativePlan)
+	resetOverrides := hasDeploymentOverrides(state.EnvVars, state.SecretEnvVars, state.SecretRefs, state.ContainerArgs)
+	if err := r.mergeTemplateOverrides(plan, &nativePlan, resetOverrides); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update deployment, got error: %s", err))
+		return
+	}
+
+	// A known hash in the plan means the password stays the same. A generated password isn't
+	// known anymore, so it's left out of the request and the controller keeps the current one.
+	passwordChanged := plan.AuthPasswordHash.IsUnknown()
+	password, generated := plan.AuthPassword.ValueString(), false
+	if passwordChanged {
+		var err error
+		password, generated, err = newDeploymentPassword(plan.AuthPassword)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update deployment, got error: %s", err))
+			return
+		}
+	}
+	nativePlan.AuthPassword = password
+
+	_, err := r.client.UpdateDeployment(state.ID.ValueString(), state.ProjectID.ValueString(), nativePlan)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating deployment",
-			"Could not create deployment, unexpected error: "+err.Error(),
-		)
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update deployment, got error: %s", err))
 		return
 	}
 
-	// Update the state with the new resource details
-	newState := convertToDeploymentTerraformState(deployment)
+	// The deployment is updated in place, so its ID and URL stay the same
+	plan.ID = state.ID
+	plan.URL = state.URL
+	plan.AuthPassword = types.StringNull()
+	plan.GeneratedAuthPassword = types.StringNull()
+	newState := DeploymentTerraformState(plan)
+	if passwordChanged {
+		if err := setDeploymentPasswordState(&newState, password, generated); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update deployment, got error: %s", err))
+			return
+		}
+	}
+	carryDeploymentStatusState(&newState, state)
+	r.refreshDeploymentStatus(&newState)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.WaitForReady.ValueBool() && plan.Enabled.ValueBool() {
+		ready, err := r.client.WaitForDeploymentReady(ctx, state.ID.ValueString(), state.ProjectID.ValueString())
+		if ready != nil {
+			setDeploymentStatusState(&newState, ready)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Deployment Not Ready", fmt.Sprintf("Deployment %s was updated but did not become ready: %s", state.ID.ValueString(), err))
+		}
+	}
 }
 
 func (r *deploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -208,68 +662,154 @@ func (r *deploymentResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeploymentDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Call Client's DeleteDeployment method
 	_, err := r.client.DeleteDeployment(state.ID.ValueString(), state.ProjectID.ValueString())
 	if err != nil {
+		// a deployment which is already gone counts as deleted
+		if _, getErr := r.client.GetDeploymentByID(state.ID.ValueString(), state.ProjectID.ValueString()); errors.Is(getErr, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete deployment, got error: %s", err))
 		return
 	}
 
+	if state.WaitForReady.ValueBool() {
+		if err := r.client.WaitForDeploymentDeleted(ctx, state.ID.ValueString(), state.ProjectID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to confirm deployment deletion, got error: %s", err))
+			return
+		}
+	}
+
 	resp.State.RemoveResource(ctx)
 }
 
 type DeploymentTerraformState struct {
-	ID                types.String            `tfsdk:"id"`
-	Name              types.String            `tfsdk:"name"`
-	ProjectID         types.String            `tfsdk:"project_id"`
-	DeploymentImageID types.String            `tfsdk:"deployment_image_id"`
-	ContainerImage    types.String            `tfsdk:"container_image"`
-	MinReplicas       types.Int64             `tfsdk:"min_replicas"`
-	MaxReplicas       types.Int64             `tfsdk:"max_replicas"`
-	VMID              types.String            `tfsdk:"vm_id"`
-	Annotations       map[string]types.String `tfsdk:"annotations"`
-	AuthUsername      types.String            `tfsdk:"auth_username"`
-	AuthPassword      types.String            `tfsdk:"auth_password"`
-	URL               types.String            `tfsdk:"deployment_url"`
+	ID                    types.String   `tfsdk:"id"`
+	Name                  types.String   `tfsdk:"name"`
+	ProjectID             types.String   `tfsdk:"project_id"`
+	DeploymentTemplateID  types.String   `tfsdk:"deployment_template_id"`
+	DeploymentImageID     types.String   `tfsdk:"deployment_image_id"`
+	ContainerImage        types.String   `tfsdk:"container_image"`
+	RegistryCredentialID  types.String   `tfsdk:"registry_credential_id"`
+	ContainerPort         types.Int64    `tfsdk:"container_port"`
+	ContainerArgs         types.List     `tfsdk:"container_args"`
+	EnvVars               types.Map      `tfsdk:"env_vars"`
+	SecretEnvVars         types.Map      `tfsdk:"secret_env_vars"`
+	SecretRefs            types.Map      `tfsdk:"secret_refs"`
+	VolumeMounts          types.List     `tfsdk:"volume_mounts"`
+	MinReplicas           types.Int64    `tfsdk:"min_replicas"`
+	MaxReplicas           types.Int64    `tfsdk:"max_replicas"`
+	Autoscaling           types.Object   `tfsdk:"autoscaling"`
+	Enabled               types.Bool     `tfsdk:"enabled"`
+	VMID                  types.String   `tfsdk:"vm_id"`
+	Annotations           types.Map      `tfsdk:"annotations"`
+	Tags                  types.Set      `tfsdk:"tags"`
+	Nickname              types.String   `tfsdk:"nickname"`
+	AuthUsername          types.String   `tfsdk:"auth_username"`
+	AuthPassword          types.String   `tfsdk:"auth_password"`
+	AuthPasswordVersion   types.Int64    `tfsdk:"auth_password_version"`
+	AuthPasswordHash      types.String   `tfsdk:"auth_password_hash"`
+	GeneratedAuthPassword types.String   `tfsdk:"generated_auth_password"`
+	URL                   types.String   `tfsdk:"deployment_url"`
+	Status                types.String   `tfsdk:"status"`
+	RunningReplicas       types.Int64    `tfsdk:"running_replicas"`
+	CreatedAt             types.String   `tfsdk:"created_at"`
+	MachineTypeDetails    types.Map      `tfsdk:"machine_type_details"`
+	Replicas              types.List     `tfsdk:"replicas"`
+	WaitForReady          types.Bool     `tfsdk:"wait_for_ready"`
+	ReplacementStrategy   types.String   `tfsdk:"replacement_strategy"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
 }
 
-func convertToNativeMap(attributes map[string]types.String) map[string]string {
-	result := make(map[string]string)
-	for k, v := range attributes {
-		result[k] = v.ValueString()
+func convertDeploymentToNativePlan(plan DeploymentCreateRequest) DeploymentCreateRequestNative {
+	return DeploymentCreateRequestNative{
+		Name:                 plan.Name.ValueString(),
+		ProjectID:            plan.ProjectID.ValueString(),
+		DeploymentImageID:    plan.DeploymentImageID.ValueString(),
+		ContainerImage:       plan.ContainerImage.ValueString(),
+		RegistryCredentialID: plan.RegistryCredentialID.ValueString(),
+		ContainerPort:        plan.ContainerPort.ValueInt64(),
+		VolumeMounts:         deploymentVolumeMountsFromPlan(plan.VolumeMounts),
+		MinReplicas:          plan.MinReplicas.ValueInt64(),
+		MaxReplicas:          plan.MaxReplicas.ValueInt64(),
+		Autoscaling:          deploymentAutoscalingFromPlan(plan.Autoscaling),
+		Paused:               !plan.Enabled.ValueBool(),
+		VMID:                 plan.VMID.ValueString(),
+		Annotations:          deploymentAnnotationsFromPlan(plan),
+		AuthUsername:         plan.AuthUsername.ValueString(),
+		AuthPassword:         plan.AuthPassword.ValueString(),
+		URL:                  plan.URL.ValueString(),
 	}
-	return result
 }
 
-func convertDeploymentToNativePlan(plan DeploymentCreateRequest) DeploymentCreateRequestNative {
-	return DeploymentCreateRequestNative{
-		Name:              plan.Name.ValueString(),
-		ProjectID:         plan.ProjectID.ValueString(),
-		DeploymentImageID: plan.DeploymentImageID.ValueString(),
-		ContainerImage:    plan.ContainerImage.ValueString(),
-		MinReplicas:       plan.MinReplicas.ValueInt64(),
-		MaxReplicas:       plan.MaxReplicas.ValueInt64(),
-		VMID:              plan.VMID.ValueString(),
-		Annotations:       convertToNativeMap(plan.Annotations),
-		AuthUsername:      plan.AuthUsername.ValueString(),
-		AuthPassword:      plan.AuthPassword.ValueString(),
-		URL:               plan.URL.ValueString(),
+// enabledFromAPI keeps the prior value when the controller doesn't report whether the deployment is paused,
+// deployments are enabled unless stated otherwise
+func enabledFromAPI(paused *bool, prior types.Bool) types.Bool {
+	if paused != nil {
+		return types.BoolValue(!*paused)
+	}
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.BoolValue(true)
 	}
+	return prior
 }
 
-func convertToDeploymentTerraformState(deployment *Deployment) DeploymentTerraformState {
-	return DeploymentTerraformState{
-		ID:                types.StringValue(deployment.ID),
-		Name:              types.StringValue(deployment.Name),
-		ProjectID:         types.StringValue(deployment.ProjectID),
-		DeploymentImageID: types.StringValue(deployment.DeploymentImageID),
-		ContainerImage:    types.StringValue(deployment.ContainerImage),
-		MinReplicas:       types.Int64Value(deployment.MinReplicas),
-		MaxReplicas:       types.Int64Value(deployment.MaxReplicas),
-		VMID:              types.StringValue(deployment.VMID),
-		Annotations:       convertToTypesStringMap(deployment.Annotations),
-		AuthUsername:      types.StringValue(deployment.AuthUsername),
-		AuthPassword:      types.StringValue(deployment.AuthPassword),
-		URL:               types.StringValue(deployment.URL),
+// registryCredentialIDFromAPI stores the credential the controller reports, which is the credential
+// of the template when the attribute was omitted, and keeps it null when there is none
+func registryCredentialIDFromAPI(credentialID string, prior types.String) types.String {
+	if prior.IsUnknown() {
+		prior = types.StringNull()
 	}
+	return stringValueOrNull(credentialID, prior)
+}
+
+// convertToDeploymentTerraformState converts the deployment returned by the API, using the prior plan or state
+// to tell whether empty annotations were left out of the config. The password attributes are set separately,
+// so the plain text password returned by the API never reaches state.
+func convertToDeploymentTerraformState(deployment *Deployment, prior DeploymentTerraformState) DeploymentTerraformState {
+	annotations, tags, nickname := decodeDeploymentAnnotations(deployment.Annotations)
+	nicknameValue := ""
+	if nickname != nil {
+		nicknameValue = *nickname
+	}
+
+	state := DeploymentTerraformState{
+		ID:                   types.StringValue(deployment.ID),
+		Name:                 types.StringValue(deployment.Name),
+		ProjectID:            types.StringValue(deployment.ProjectID),
+		DeploymentTemplateID: prior.DeploymentTemplateID,
+		DeploymentImageID:    types.StringValue(deployment.DeploymentImageID),
+		ContainerImage:       types.StringValue(deployment.ContainerImage),
+		RegistryCredentialID: registryCredentialIDFromAPI(deployment.RegistryCredentialID, prior.RegistryCredentialID),
+		ContainerPort:        int64ValueOrNull(deployment.ContainerPort, prior.ContainerPort),
+		ContainerArgs:        containerArgsFromAPI(deployment.ContainerArgs, prior.ContainerArgs),
+		EnvVars:              envVarOverridesFromAPI(deployment.EnvVars, prior.EnvVars),
+		SecretEnvVars:        envVarOverridesFromAPI(deployment.EnvVars, prior.SecretEnvVars),
+		SecretRefs:           envVarOverridesFromAPI(deployment.SecretRefs, prior.SecretRefs),
+		VolumeMounts:         deploymentVolumeMountsValue(deployment.VolumeMounts, prior.VolumeMounts),
+		MinReplicas:          types.Int64Value(deployment.MinReplicas),
+		MaxReplicas:          types.Int64Value(deployment.MaxReplicas),
+		Autoscaling:          deploymentAutoscalingValue(deployment.Autoscaling, prior.Autoscaling),
+		Enabled:              enabledFromAPI(deployment.Paused, prior.Enabled),
+		VMID:                 types.StringValue(deployment.VMID),
+		Annotations:          mapValueFromStrings(annotations, prior.Annotations),
+		Tags:                 setValueFromStrings(tags, prior.Tags),
+		Nickname:             stringValueOrNull(nicknameValue, prior.Nickname),
+		AuthUsername:         types.StringValue(deployment.AuthUsername),
+		URL:                  types.StringValue(deployment.URL),
+	}
+	setDeploymentStatusState(&state, deployment)
+
+	return state
 }
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// Annotations the controller reads for the console, managed through the tags and nickname attributes
+const (
+	tagsAnnotation     = "tags"
+	nicknameAnnotation = "nickname"
+)
+
+var _ resource.ResourceWithUpgradeState = &deploymentResource{}
+
+// encodeDeploymentAnnotations adds the tags and nickname to the annotations, in the format the console uses
+func encodeDeploymentAnnotations(annotations map[string]string, tags []string, nickname *string) (map[string]string, error) {
+	encoded := make(map[string]string, len(annotations)+2)
+	for k, v := range annotations {
+		encoded[k] = v
+	}
+
+	if tags != nil {
+		sorted := append([]string{}, tags...)
+		sort.Strings(sorted)
+		value, err := json.Marshal(sorted)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode tags: %v", err)
+		}
+		encoded[tagsAnnotation] = string(value)
+	}
+
+	if nickname != nil {
+		encoded[nicknameAnnotation] = *nickname
+	}
+
+	return encoded, nil
+}
+
+// decodeDeploymentAnnotations splits the tags and nickname off the annotations returned by the controller.
+// Tags which can't be parsed are left in the annotations, so the difference shows up in the plan.
+func decodeDeploymentAnnotations(annotations map[string]string) (rest map[string]string, tags []string, nickname *string) {
+	rest = make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		rest[k] = v
+	}
+
+	if value, ok := rest[tagsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &tags); err == nil {
+			delete(rest, tagsAnnotation)
+		}
+	}
+
+	if value, ok := rest[nicknameAnnotation]; ok {
+		nickname = &value
+		delete(rest, nicknameAnnotation)
+	}
+
+	return rest, tags, nickname
+}
+
+// deploymentAnnotationsFromPlan returns the annotations sent to the controller for the planned deployment
+func deploymentAnnotationsFromPlan(plan DeploymentCreateRequest) map[string]string {
+	var tags []string
+	if !plan.Tags.IsNull() {
+		tags = stringSliceFromSetValue(plan.Tags)
+	}
+
+	var nickname *string
+	if !plan.Nickname.IsNull() {
+		nickname = plan.Nickname.ValueStringPointer()
+	}
+
+	// the tags are plain strings, so encoding them can't fail
+	annotations, _ := encodeDeploymentAnnotations(stringMapFromValue(plan.Annotations), tags, nickname)
+	return annotations
+}
+
+func stringSliceFromSetValue(set types.Set) []string {
+	values := make([]string, 0, len(set.Elements()))
+	for _, element := range set.Elements() {
+		if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			values = append(values, value.ValueString())
+		}
+	}
+	return values
+}
+
+func setValueFromStrings(values []string, prior types.Set) types.Set {
+	if len(values) == 0 && prior.IsNull() {
+		return types.SetNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, len(values))
+	for i, v := range values {
+		elements[i] = types.StringValue(v)
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// deploymentStateDefaults are the schema defaults of attributes which version 0 states were written without,
+// so upgraded states don't show them as changing from null on the next plan
+var deploymentStateDefaults = map[string]interface{}{
+	"wait_for_ready":       true,
+	"replacement_strategy": replacementStrategyDestroyBeforeCreate,
+	"enabled":              true,
+}
+
+// setDeploymentStateDefaults sets the defaulted attributes missing from a raw state to their defaults
+func setDeploymentStateDefaults(state map[string]interface{}) {
+	for name, value := range deploymentStateDefaults {
+		if state[name] == nil {
+			state[name] = value
+		}
+	}
+}
+
+func (r *deploymentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: upgradeDeploymentStateV0,
+		},
+	}
+}
+
+// upgradeDeploymentStateV0 moves the tags and nickname annotations into their own attributes. The raw JSON
+// state is upgraded instead of decoding it with a prior schema, since version 0 states were written by
+// provider releases with different sets of attributes.
+func upgradeDeploymentStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "Expected a JSON state for version 0 of theta_deployment")
+		return
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to decode version 0 state of theta_deployment: %s", err))
+		return
+	}
+
+	if annotations, ok := state["annotations"].(map[string]interface{}); ok {
+		rest, tags, nickname := decodeDeploymentAnnotations(convertToStringMap(annotations))
+		state["annotations"] = rest
+		if len(rest) == 0 {
+			state["annotations"] = nil
+		}
+		// empty values are left null, matching configs which simply drop them
+		if len(tags) > 0 {
+			state["tags"] = tags
+		}
+		if nickname != nil && *nickname != "" {
+			state["nickname"] = *nickname
+		}
+	}
+
+	// auth_password is write-only now and dropped from upgraded states, so states written before
+	// the hash existed keep the hash of their password instead
+	if password, ok := state["auth_password"].(string); ok && state["auth_password_hash"] == nil {
+		hash, err := hashPassword(password)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to hash the password of theta_deployment: %s", err))
+			return
+		}
+		state["auth_password_hash"] = hash
+	}
+	state["auth_password"] = nil
+	setDeploymentStateDefaults(state)
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to encode upgraded state of theta_deployment: %s", err))
+		return
+	}
+
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Metrics the controller can scale deployments on
+const (
+	autoscalingMetricConcurrentRequests = "concurrent_requests"
+	autoscalingMetricGPUUtilization     = "gpu_utilization"
+)
+
+var deploymentAutoscalingAttrTypes = map[string]attr.Type{
+	"metric":                             types.StringType,
+	"target":                             types.Int64Type,
+	"scale_up_cooldown_seconds":          types.Int64Type,
+	"scale_down_cooldown_seconds":        types.Int64Type,
+	"scale_to_zero_idle_timeout_seconds": types.Int64Type,
+}
+
+// validateDeploymentAutoscaling checks the autoscaling policy against the replica bounds it scales between
+func validateDeploymentAutoscaling(ctx context.Context, config tfsdk.Config, minReplicas, maxReplicas types.Int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var autoscaling types.Object
+	diags.Append(config.GetAttribute(ctx, path.Root("autoscaling"), &autoscaling)...)
+	if diags.HasError() || autoscaling.IsNull() || autoscaling.IsUnknown() {
+		return diags
+	}
+
+	metric := autoscalingStringAttribute(autoscaling, "metric")
+	target := autoscalingInt64Attribute(autoscaling, "target")
+	if metric.ValueString() == autoscalingMetricGPUUtilization && target.ValueInt64() > 100 {
+		diags.AddAttributeError(
+			path.Root("autoscaling").AtName("target"),
+			"Invalid Autoscaling Target",
+			fmt.Sprintf("The target of gpu_utilization is a percentage and must not be greater than 100, got %d", target.ValueInt64()),
+		)
+	}
+
+	// values coming from other resources are only known at apply time
+	if minReplicas.IsNull() || minReplicas.IsUnknown() || maxReplicas.IsNull() || maxReplicas.IsUnknown() {
+		return diags
+	}
+
+	if minReplicas.ValueInt64() == maxReplicas.ValueInt64() {
+		diags.AddAttributeError(
+			path.Root("autoscaling"),
+			"Invalid Autoscaling Policy",
+			fmt.Sprintf("min_replicas and max_replicas are both %d, so there is nothing to scale. Raise max_replicas or remove autoscaling", minReplicas.ValueInt64()),
+		)
+	}
+
+	scaleToZero := !autoscalingInt64Attribute(autoscaling, "scale_to_zero_idle_timeout_seconds").IsNull()
+	if scaleToZero && minReplicas.ValueInt64() != 0 {
+		diags.AddAttributeError(
+			path.Root("autoscaling").AtName("scale_to_zero_idle_timeout_seconds"),
+			"Invalid Autoscaling Policy",
+			fmt.Sprintf("Scaling to zero requires min_replicas to be 0, got %d", minReplicas.ValueInt64()),
+		)
+	}
+	if !scaleToZero && minReplicas.ValueInt64() == 0 {
+		diags.AddAttributeError(
+			path.Root("autoscaling").AtName("scale_to_zero_idle_timeout_seconds"),
+			"Invalid Autoscaling Policy",
+			"min_replicas is 0, set scale_to_zero_idle_timeout_seconds to say when the deployment scales to zero",
+		)
+	}
+
+	return diags
+}
+
+// deploymentAutoscalingFromPlan returns the scaling configuration sent to the controller, nil removes it
+func deploymentAutoscalingFromPlan(autoscaling types.Object) *DeploymentAutoscaling {
+	if autoscaling.IsNull() || autoscaling.IsUnknown() {
+		return nil
+	}
+
+	return &DeploymentAutoscaling{
+		Metric:                        autoscalingStringAttribute(autoscaling, "metric").ValueString(),
+		Target:                        autoscalingInt64Attribute(autoscaling, "target").ValueInt64(),
+		ScaleUpCooldownSeconds:        autoscalingInt64Attribute(autoscaling, "scale_up_cooldown_seconds").ValueInt64(),
+		ScaleDownCooldownSeconds:      autoscalingInt64Attribute(autoscaling, "scale_down_cooldown_seconds").ValueInt64(),
+		ScaleToZeroIdleTimeoutSeconds: autoscalingInt64Attribute(autoscaling, "scale_to_zero_idle_timeout_seconds").ValueInt64(),
+	}
+}
+
+// deploymentAutoscalingValue builds the autoscaling attribute, leaving the optional settings the controller
+// reports as 0 null when they were null before
+func deploymentAutoscalingValue(autoscaling *DeploymentAutoscaling, prior types.Object) types.Object {
+	if autoscaling == nil {
+		return types.ObjectNull(deploymentAutoscalingAttrTypes)
+	}
+
+	return types.ObjectValueMust(deploymentAutoscalingAttrTypes, map[string]attr.Value{
+		"metric":                             types.StringValue(autoscaling.Metric),
+		"target":                             types.Int64Value(autoscaling.Target),
+		"scale_up_cooldown_seconds":          int64ValueOrNull(autoscaling.ScaleUpCooldownSeconds, autoscalingInt64Attribute(prior, "scale_up_cooldown_seconds")),
+		"scale_down_cooldown_seconds":        int64ValueOrNull(autoscaling.ScaleDownCooldownSeconds, autoscalingInt64Attribute(prior, "scale_down_cooldown_seconds")),
+		"scale_to_zero_idle_timeout_seconds": int64ValueOrNull(autoscaling.ScaleToZeroIdleTimeoutSeconds, autoscalingInt64Attribute(prior, "scale_to_zero_idle_timeout_seconds")),
+	})
+}
+
+func autoscalingStringAttribute(autoscaling types.Object, name string) types.String {
+	if value, ok := autoscaling.Attributes()[name].(types.String); ok {
+		return value
+	}
+	return types.StringNull()
+}
+
+func autoscalingInt64Attribute(autoscaling types.Object, name string) types.Int64 {
+	if value, ok := autoscaling.Attributes()[name].(types.Int64); ok {
+		return value
+	}
+	return types.Int64Null()
+}
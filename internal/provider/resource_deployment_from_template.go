@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ResourceWithConfigValidators = &deploymentResource{}
+	_ resource.ResourceWithModifyPlan       = &deploymentResource{}
+)
+
+func (r *deploymentResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(path.MatchRoot("deployment_template_id"), path.MatchRoot("deployment_image_id")),
+		resourcevalidator.AtLeastOneOf(path.MatchRoot("deployment_template_id"), path.MatchRoot("container_image")),
+	}
+}
+
+// ModifyPlan resolves deployment_template_id, so the plan shows the image and port the deployment will use
+func (r *deploymentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to resolve when the deployment is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config DeploymentCreateRequest
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	var state DeploymentTerraformState
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.DeploymentTemplateID.IsNull() {
+		if config.ContainerPort.IsNull() && plan.ContainerPort.IsUnknown() {
+			plan.ContainerPort = types.Int64Null()
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		}
+		return
+	}
+
+	// values coming from other resources are resolved in Create instead, as is everything before the provider is configured
+	if plan.DeploymentTemplateID.IsUnknown() || plan.ProjectID.IsUnknown() || r.client == nil {
+		return
+	}
+
+	templateChanged := !plan.DeploymentTemplateID.Equal(state.DeploymentTemplateID)
+	if !templateChanged && plan.ContainerImage.Equal(state.ContainerImage) &&
+		!plan.DeploymentImageID.IsUnknown() && !plan.ContainerPort.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.resolveDeploymentTemplate(&plan, config, templateChanged)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// resolveDeploymentTemplate looks up the template referenced by the plan and applies it with applyDeploymentTemplate
+func (r *deploymentResource) resolveDeploymentTemplate(plan *DeploymentCreateRequest, config DeploymentCreateRequest, templateChanged bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	template, err := r.client.FindDeploymentTemplate(plan.ProjectID.ValueString(), plan.DeploymentTemplateID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			diags.AddAttributeError(
+				path.Root("deployment_template_id"),
+				"Deployment Template Not Found",
+				fmt.Sprintf("No custom template in project %s and no standard template has the ID %s", plan.ProjectID.ValueString(), plan.DeploymentTemplateID.ValueString()),
+			)
+		} else {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read deployment template, got error: %s", err))
+		}
+		return diags
+	}
+
+	diags.Append(applyDeploymentTemplate(plan, config, template, templateChanged)...)
+	return diags
+}
+
+// applyDeploymentTemplate fills the attributes left out of the config from the template and checks the configured
+// ones against it. Values already planned from state are only replaced when the deployment switches templates.
+func applyDeploymentTemplate(plan *DeploymentCreateRequest, config DeploymentCreateRequest, template *DeploymentTemplate, templateChanged bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	fromTemplate := func(configured, planned attr.Value) bool {
+		return configured.IsNull() && (planned.IsUnknown() || templateChanged)
+	}
+	known := func(configured attr.Value) bool {
+		return !configured.IsNull() && !configured.IsUnknown()
+	}
+
+	if fromTemplate(config.DeploymentImageID, plan.DeploymentImageID) {
+		plan.DeploymentImageID = types.StringValue(template.ID)
+	} else if known(config.DeploymentImageID) && config.DeploymentImageID.ValueString() != template.ID {
+		diags.AddAttributeError(
+			path.Root("deployment_image_id"),
+			"Conflicting Deployment Image",
+			fmt.Sprintf("deployment_image_id %s doesn't match deployment_template_id %s, omit it to use the template", config.DeploymentImageID.ValueString(), template.ID),
+		)
+	}
+
+	if fromTemplate(config.ContainerImage, plan.ContainerImage) {
+		if len(template.ContainerImages) == 0 {
+			diags.AddAttributeError(
+				path.Root("container_image"),
+				"Missing Container Image",
+				fmt.Sprintf("Template %s has no container images, container_image has to be set", template.ID),
+			)
+		} else {
+			plan.ContainerImage = types.StringValue(template.ContainerImages[0])
+		}
+	} else if known(config.ContainerImage) && !containsString(template.ContainerImages, config.ContainerImage.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("container_image"),
+			"Invalid Container Image",
+			fmt.Sprintf("Container image %s is not one of the container images of template %s: %s",
+				config.ContainerImage.ValueString(), template.ID, strings.Join(template.ContainerImages, ", ")),
+		)
+	}
+
+	if fromTemplate(config.ContainerPort, plan.ContainerPort) {
+		plan.ContainerPort = types.Int64Null()
+		if template.ContainerPort != 0 {
+			plan.ContainerPort = types.Int64Value(template.ContainerPort)
+		}
+	}
+
+	return diags
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveUnknownTemplateValues applies the template at apply time when its ID wasn't known during plan
+func (r *deploymentResource) resolveUnknownTemplateValues(ctx context.Context, plan *DeploymentCreateRequest, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !plan.DeploymentTemplateID.IsNull() &&
+		(plan.DeploymentImageID.IsUnknown() || plan.ContainerImage.IsUnknown() || plan.ContainerPort.IsUnknown()) {
+		var configValues DeploymentCreateRequest
+		diags.Append(config.Get(ctx, &configValues)...)
+		if diags.HasError() {
+			return diags
+		}
+		diags.Append(r.resolveDeploymentTemplate(plan, configValues, false)...)
+	}
+
+	if plan.ContainerPort.IsUnknown() {
+		plan.ContainerPort = types.Int64Null()
+	}
+
+	return diags
+}
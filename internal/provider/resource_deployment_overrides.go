@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// hasDeploymentOverrides reports whether any of the template's env vars, secret references or arguments are overridden
+func hasDeploymentOverrides(envVars, secretEnvVars, secretRefs types.Map, containerArgs types.List) bool {
+	return !envVars.IsNull() || !secretEnvVars.IsNull() || !secretRefs.IsNull() || !containerArgs.IsNull()
+}
+
+// mergeTemplateOverrides sets the env vars and arguments of the request to the template values with the
+// deployment overrides on top, since whatever the deployment sends replaces the template values on the
+// controller. With resetOverrides the template values are sent even if nothing is overridden anymore,
+// which undoes overrides removed from the config.
+func (r *deploymentResource) mergeTemplateOverrides(plan DeploymentCreateRequest, nativePlan *DeploymentCreateRequestNative, resetOverrides bool) error {
+	if !resetOverrides && !hasDeploymentOverrides(plan.EnvVars, plan.SecretEnvVars, plan.SecretRefs, plan.ContainerArgs) {
+		return nil
+	}
+
+	// deployments created before deployment_template_id existed reference their template through the image ID
+	templateID := plan.DeploymentTemplateID.ValueString()
+	if plan.DeploymentTemplateID.IsNull() {
+		templateID = plan.DeploymentImageID.ValueString()
+	}
+
+	// without the template its values can't be sent along, and the overrides alone would replace them
+	template, err := r.client.FindDeploymentTemplate(plan.ProjectID.ValueString(), templateID)
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("template %s of the deployment wasn't found, so the overrides can't be merged with its values: %w", templateID, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	nativePlan.EnvVars, nativePlan.SecretRefs, nativePlan.ContainerArgs = mergeDeploymentOverrides(template, plan)
+	return nil
+}
+
+func mergeDeploymentOverrides(template *DeploymentTemplate, plan DeploymentCreateRequest) (map[string]string, map[string]string, []string) {
+	envVars := make(map[string]string)
+	for k, v := range template.EnvVars {
+		envVars[k] = v
+	}
+	secretRefs := make(map[string]string)
+	for k, v := range template.SecretRefs {
+		secretRefs[k] = v
+	}
+	containerArgs := template.ContainerArgs
+
+	// a variable is either set directly or from a secret, so an override of either kind replaces both
+	for k, v := range stringMapFromValue(plan.EnvVars) {
+		envVars[k] = v
+		delete(secretRefs, k)
+	}
+	for k, v := range stringMapFromValue(plan.SecretEnvVars) {
+		envVars[k] = v
+		delete(secretRefs, k)
+	}
+	for k, v := range stringMapFromValue(plan.SecretRefs) {
+		secretRefs[k] = v
+		delete(envVars, k)
+	}
+
+	// arguments can't be merged element by element, so configured arguments replace the template's
+	if !plan.ContainerArgs.IsNull() {
+		containerArgs = stringSliceFromValue(plan.ContainerArgs)
+	}
+
+	return envVars, secretRefs, containerArgs
+}
+
+// envVarOverridesFromAPI picks the overridden variables out of the merged environment returned by the controller.
+// Variables missing from the controller are dropped, so the next plan sets them again.
+func envVarOverridesFromAPI(merged map[string]string, prior types.Map) types.Map {
+	// the controller didn't report the environment of the deployment
+	if merged == nil {
+		return prior
+	}
+
+	overrides := make(map[string]string)
+	for k := range prior.Elements() {
+		if v, ok := merged[k]; ok {
+			overrides[k] = v
+		}
+	}
+
+	return mapValueFromStrings(overrides, prior)
+}
+
+func containerArgsFromAPI(containerArgs []string, prior types.List) types.List {
+	if containerArgs == nil || prior.IsNull() {
+		return prior
+	}
+
+	return listValueFromStrings(containerArgs, prior)
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	generatedPasswordLength  = 32
+	generatedPasswordCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	passwordHashSaltLength   = 16
+)
+
+// argon2id parameters, the second recommended option of RFC 9106. They are stored with every hash,
+// so changing them only affects new hashes.
+const (
+	passwordHashTime    = 3
+	passwordHashMemory  = 64 * 1024
+	passwordHashThreads = 4
+	passwordHashLength  = 32
+)
+
+// generatePassword returns a random password made of letters and digits, which every endpoint accepts
+func generatePassword() (string, error) {
+	charsetLength := big.NewInt(int64(len(generatedPasswordCharset)))
+
+	password := make([]byte, generatedPasswordLength)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, charsetLength)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate password: %v", err)
+		}
+		password[i] = generatedPasswordCharset[n.Int64()]
+	}
+
+	return string(password), nil
+}
+
+// hashPassword returns an argon2id hash of the password in the PHC string format,
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, passwordHashSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %v", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, passwordHashTime, passwordHashMemory, passwordHashThreads, passwordHashLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, passwordHashMemory, passwordHashTime, passwordHashThreads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// verifyPasswordHash reports whether the password matches a hash created by hashPassword
+func verifyPasswordHash(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return false
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(computed, key) == 1
+}
+
+// authPasswordHashPlanModifier keeps the stored hash when neither the configured password nor the
+// password version changes, so unrelated updates don't show the hash as changing. auth_password is
+// write-only and never in state, so a changed password is detected by checking it against the hash,
+// which plans an update even when nothing else changes.
+type authPasswordHashPlanModifier struct{}
+
+func (m authPasswordHashPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the prior hash unless the password, its version or the deployment itself changes."
+}
+
+func (m authPasswordHashPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m authPasswordHashPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || req.StateValue.IsNull() {
+		return
+	}
+
+	var configPassword types.String
+	var planVersion, stateVersion types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_password"), &configPassword)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("auth_password_version"), &planVersion)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("auth_password_version"), &stateVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	passwordChanged := configPassword.IsUnknown() ||
+		(!configPassword.IsNull() && !verifyPasswordHash(req.StateValue.ValueString(), configPassword.ValueString()))
+	if passwordChanged || !planVersion.Equal(stateVersion) {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	// a replacement deployment gets a freshly hashed (and possibly generated) password
+	replaced, diags := deploymentReplacedInUpdate(ctx, req.State, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if replaced {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+// newDeploymentPassword returns the password a new or updated deployment should use,
+// generating one when auth_password isn't configured
+func newDeploymentPassword(configured types.String) (password string, generated bool, err error) {
+	if !configured.IsNull() {
+		return configured.ValueString(), false, nil
+	}
+
+	password, err = generatePassword()
+	return password, true, err
+}
+
+// setDeploymentPasswordState records the password sent to the controller. Only the hash is kept,
+// a generated password is exposed through generated_auth_password until the next refresh.
+func setDeploymentPasswordState(state *DeploymentTerraformState, password string, generated bool) error {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	state.AuthPassword = types.StringNull()
+	state.AuthPasswordHash = types.StringValue(hash)
+	state.GeneratedAuthPassword = types.StringNull()
+	if generated {
+		state.GeneratedAuthPassword = types.StringValue(password)
+	}
+
+	return nil
+}
+
+// readDeploymentPasswordState carries the password attributes over from the prior state, comparing the
+// password returned by the controller against the stored hash instead of writing it into state.
+// When they don't match the hash is cleared so the next plan restores the password.
+func readDeploymentPasswordState(newState *DeploymentTerraformState, state DeploymentTerraformState, apiPassword string) {
+	newState.AuthPassword = types.StringNull()
+	newState.AuthPasswordHash = state.AuthPasswordHash
+	newState.AuthPasswordVersion = state.AuthPasswordVersion
+	newState.GeneratedAuthPassword = types.StringNull()
+
+	if apiPassword == "" || newState.AuthPasswordHash.IsNull() {
+		return
+	}
+
+	if !verifyPasswordHash(newState.AuthPasswordHash.ValueString(), apiPassword) {
+		newState.AuthPasswordHash = types.StringNull()
+	}
+}
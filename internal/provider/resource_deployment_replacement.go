@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Terraform deletes the existing deployment before creating its replacement
+	replacementStrategyDestroyBeforeCreate = "destroy_before_create"
+	// The provider creates the replacement, waits for it to be healthy and only then deletes the existing deployment
+	replacementStrategyCreateBeforeDestroy = "create_before_destroy"
+)
+
+// Attributes which can't be changed on an existing deployment
+var deploymentImmutableAttributes = []string{
+	"name",
+	"project_id",
+	"deployment_template_id",
+	"deployment_image_id",
+	"container_image",
+	"vm_id",
+}
+
+// requiresReplaceUnlessCreateBeforeDestroy forces a new deployment when an immutable attribute changes,
+// unless the provider was asked to handle the replacement itself in Update
+func requiresReplaceUnlessCreateBeforeDestroy() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var strategy types.String
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("replacement_strategy"), &strategy)...)
+			resp.RequiresReplace = strategy.ValueString() != replacementStrategyCreateBeforeDestroy
+		},
+		"Changing this forces a new deployment, unless replacement_strategy is create_before_destroy.",
+		"Changing this forces a new deployment, unless `replacement_strategy` is `create_before_destroy`.",
+	)
+}
+
+// useStateForUnknownUnlessReplaced keeps the computed identity of a deployment stable across updates,
+// except when Update is going to swap it for a new deployment
+type useStateForUnknownUnlessReplaced struct{}
+
+func (m useStateForUnknownUnlessReplaced) Description(ctx context.Context) string {
+	return "Keeps the prior state value unless the deployment is replaced by the provider."
+}
+
+func (m useStateForUnknownUnlessReplaced) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownUnlessReplaced) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	replaced, diags := deploymentReplacedInUpdate(ctx, req.State, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if replaced {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+// deploymentReplacedInUpdate reports whether the planned change swaps the deployment for a new one in Update
+func deploymentReplacedInUpdate(ctx context.Context, state tfsdk.State, plan tfsdk.Plan) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var strategy types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("replacement_strategy"), &strategy)...)
+	if strategy.ValueString() != replacementStrategyCreateBeforeDestroy {
+		return false, diags
+	}
+
+	for _, name := range deploymentImmutableAttributes {
+		var stateValue, planValue types.String
+		diags.Append(state.GetAttribute(ctx, path.Root(name), &stateValue)...)
+		diags.Append(plan.GetAttribute(ctx, path.Root(name), &planValue)...)
+		if !planValue.Equal(stateValue) {
+			return true, diags
+		}
+	}
+
+	return false, diags
+}
+
+func deploymentRequiresReplacement(plan DeploymentCreateRequest, state DeploymentTerraformState) bool {
+	return !plan.Name.Equal(state.Name) ||
+		!plan.ProjectID.Equal(state.ProjectID) ||
+		!plan.DeploymentTemplateID.Equal(state.DeploymentTemplateID) ||
+		!plan.DeploymentImageID.Equal(state.DeploymentImageID) ||
+		!plan.ContainerImage.Equal(state.ContainerImage) ||
+		!plan.VMID.Equal(state.VMID)
+}
+
+// replaceDeployment creates the planned deployment next to the existing one and only deletes the
+// existing deployment once the new one is healthy. If the new deployment never becomes healthy it is
+// removed again and the state keeps pointing at the existing deployment.
+func (r *deploymentResource) replaceDeployment(ctx context.Context, plan DeploymentCreateRequest, state DeploymentTerraformState, resp *resource.UpdateResponse) {
+	// the current password may have been generated and isn't known anymore, so the replacement gets a new one
+	password, generated, err := newDeploymentPassword(plan.AuthPassword)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating replacement deployment", err.Error())
+		return
+	}
+
+	nativePlan := convertDeploymentToNativePlan(plan)
+	nativePlan.AuthPassword = password
+	if err := r.mergeTemplateOverrides(plan, &nativePlan, false); err != nil {
+		resp.Diagnostics.AddError("Error creating replacement deployment", err.Error())
+		return
+	}
+
+	deployment, err := r.client.CreateDeployment(nativePlan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating replacement deployment",
+			fmt.Sprintf("Could not create replacement deployment, the existing deployment %s was kept: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+	log.Printf("DEBUG: Created replacement deployment %s for %s", deployment.ID, state.ID.ValueString())
+
+	// A paused replacement never gets running replicas, so there's nothing to wait for
+	var ready *Deployment
+	if plan.Enabled.ValueBool() {
+		ready, err = r.client.WaitForDeploymentReady(ctx, deployment.ID, deployment.ProjectID)
+	}
+	if err != nil {
+		// Roll back, the existing deployment was never touched so the prior state is still correct
+		if _, deleteErr := r.client.DeleteDeployment(deployment.ID, deployment.ProjectID); deleteErr != nil {
+			resp.Diagnostics.AddError(
+				"Rollback Failed",
+				fmt.Sprintf("Replacement deployment %s did not become ready and could not be deleted, please delete it manually: %s", deployment.ID, deleteErr),
+			)
+		}
+		resp.Diagnostics.AddError(
+			"Replacement Deployment Not Ready",
+			fmt.Sprintf("Replacement deployment %s did not become ready, the existing deployment %s was kept: %s", deployment.ID, state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// The replacement is healthy, from here on it's the deployment tracked in state
+	newState := convertToDeploymentTerraformState(deployment, DeploymentTerraformState(plan))
+	newState.WaitForReady = plan.WaitForReady
+	newState.ReplacementStrategy = plan.ReplacementStrategy
+	newState.Timeouts = plan.Timeouts
+	newState.AuthPasswordVersion = plan.AuthPasswordVersion
+	if ready != nil {
+		setDeploymentStatusState(&newState, ready)
+	} else {
+		r.refreshDeploymentStatus(&newState)
+	}
+	if err := setDeploymentPasswordState(&newState, password, generated); err != nil {
+		resp.Diagnostics.AddError("Error creating replacement deployment", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.DeleteDeployment(state.ID.ValueString(), state.ProjectID.ValueString()); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Previous Deployment Not Deleted",
+			fmt.Sprintf("Deployment %s was replaced by %s but could not be deleted, please delete it manually: %s", state.ID.ValueString(), deployment.ID, err),
+		)
+		return
+	}
+
+	if plan.WaitForReady.ValueBool() {
+		if err := r.client.WaitForDeploymentDeleted(ctx, state.ID.ValueString(), state.ProjectID.ValueString()); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Previous Deployment Not Deleted",
+				fmt.Sprintf("Unable to confirm deletion of replaced deployment %s: %s", state.ID.ValueString(), err),
+			)
+		}
+	}
+}
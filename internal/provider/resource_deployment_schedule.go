@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource for the scaling schedule of a deployment, run by the controller
+type deploymentScheduleResource struct {
+	client *Client
+}
+
+var (
+	_ resource.ResourceWithImportState    = &deploymentScheduleResource{}
+	_ resource.ResourceWithValidateConfig = &deploymentScheduleResource{}
+)
+
+func DeploymentScheduleResource() resource.Resource {
+	return &deploymentScheduleResource{}
+}
+
+type DeploymentScheduleState struct {
+	ID           types.String                  `tfsdk:"id"`
+	DeploymentID types.String                  `tfsdk:"deployment_id"`
+	ProjectID    types.String                  `tfsdk:"project_id"`
+	TimeZone     types.String                  `tfsdk:"time_zone"`
+	Rules        []DeploymentScheduleRuleModel `tfsdk:"rules"`
+}
+
+type DeploymentScheduleRuleModel struct {
+	Cron        types.String `tfsdk:"cron"`
+	MinReplicas types.Int64  `tfsdk:"min_replicas"`
+	MaxReplicas types.Int64  `tfsdk:"max_replicas"`
+}
+
+func (r *deploymentScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_deployment_schedule"
+}
+
+func (r *deploymentScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for scaling a deployment on a schedule, for example down to zero replicas overnight. " +
+			"When a rule fires the controller scales the deployment between the replica bounds of the rule. While a schedule is " +
+			"attached, `theta_deployment` keeps its configured `min_replicas` and `max_replicas` in state instead of reporting " +
+			"the scheduled bounds as drift. Applying other changes to the deployment sets the configured bounds again until the next rule fires",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the schedule, the same as the ID of the deployment",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deployment_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the deployment",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project of the deployment",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"time_zone": schema.StringAttribute{
+				MarkdownDescription: "The IANA time zone the cron expressions are evaluated in, for example `Europe/Berlin` or `UTC`",
+				Required:            true,
+				Validators: []validator.String{
+					timeZoneValidator{},
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				MarkdownDescription: "The scaling rules of the schedule",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cron": schema.StringAttribute{
+							MarkdownDescription: "When the rule fires, as a cron expression with five fields: minute, hour, day of month, month and day of week",
+							Required:            true,
+							Validators: []validator.String{
+								cronValidator{},
+							},
+						},
+						"min_replicas": schema.Int64Attribute{
+							MarkdownDescription: "The minimum number of replicas from then on",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+						"max_replicas": schema.Int64Attribute{
+							MarkdownDescription: "The maximum number of replicas from then on, 0 scales the deployment to zero",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *deploymentScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DeploymentScheduleState
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, rule := range config.Rules {
+		// values coming from other resources are only known at apply time
+		if rule.MinReplicas.IsNull() || rule.MinReplicas.IsUnknown() || rule.MaxReplicas.IsNull() || rule.MaxReplicas.IsUnknown() {
+			continue
+		}
+
+		if rule.MinReplicas.ValueInt64() > rule.MaxReplicas.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules").AtListIndex(i).AtName("min_replicas"),
+				"Invalid Replica Bounds",
+				fmt.Sprintf("min_replicas (%d) must not be greater than max_replicas (%d)", rule.MinReplicas.ValueInt64(), rule.MaxReplicas.ValueInt64()),
+			)
+		}
+	}
+}
+
+func (r *deploymentScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *deploymentScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DeploymentScheduleState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.PutDeploymentSchedule(convertToDeploymentSchedule(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create deployment schedule, got error: %s", err))
+		return
+	}
+
+	state := convertToDeploymentScheduleState(schedule, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *deploymentScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DeploymentScheduleState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.GetDeploymentSchedule(state.DeploymentID.ValueString(), state.ProjectID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Deployment Schedule Not Found", fmt.Sprintf("Deployment %s has no schedule anymore, it will be created again.", state.DeploymentID.ValueString()))
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployment schedule, got error: %s", err))
+		}
+		return
+	}
+
+	newState := convertToDeploymentScheduleState(schedule, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *deploymentScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DeploymentScheduleState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.PutDeploymentSchedule(convertToDeploymentSchedule(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update deployment schedule, got error: %s", err))
+		return
+	}
+
+	state := convertToDeploymentScheduleState(schedule, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *deploymentScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DeploymentScheduleState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDeploymentSchedule(state.DeploymentID.ValueString(), state.ProjectID.ValueString())
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete deployment schedule, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <project_id>/<deployment_id>
+func (r *deploymentScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, deploymentID, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || deploymentID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id/deployment_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deployment_id"), deploymentID)...)
+}
+
+func convertToDeploymentSchedule(plan DeploymentScheduleState) DeploymentSchedule {
+	schedule := DeploymentSchedule{
+		DeploymentID: plan.DeploymentID.ValueString(),
+		ProjectID:    plan.ProjectID.ValueString(),
+		TimeZone:     plan.TimeZone.ValueString(),
+		Rules:        make([]DeploymentScheduleRule, 0, len(plan.Rules)),
+	}
+
+	for _, rule := range plan.Rules {
+		schedule.Rules = append(schedule.Rules, DeploymentScheduleRule{
+			Cron:        rule.Cron.ValueString(),
+			MinReplicas: rule.MinReplicas.ValueInt64(),
+			MaxReplicas: rule.MaxReplicas.ValueInt64(),
+		})
+	}
+
+	return schedule
+}
+
+func convertToDeploymentScheduleState(schedule *DeploymentSchedule, prior DeploymentScheduleState) DeploymentScheduleState {
+	state := DeploymentScheduleState{
+		ID:           prior.DeploymentID,
+		DeploymentID: prior.DeploymentID,
+		ProjectID:    prior.ProjectID,
+		TimeZone:     types.StringValue(schedule.TimeZone),
+	}
+
+	for _, rule := range schedule.Rules {
+		state.Rules = append(state.Rules, DeploymentScheduleRuleModel{
+			Cron:        types.StringValue(rule.Cron),
+			MinReplicas: types.Int64Value(rule.MinReplicas),
+			MaxReplicas: types.Int64Value(rule.MaxReplicas),
+		})
+	}
+
+	return state
+}
+
+// keepScheduledReplicaBounds keeps the replica bounds of the deployment state while a schedule is attached,
+// since the controller changes them whenever a rule fires and applying them again would undo the scaling
+func keepScheduledReplicaBounds(c *Client, deployment *Deployment, state DeploymentTerraformState) error {
+	// imported deployments take their bounds from the controller
+	if state.MinReplicas.IsNull() || state.MaxReplicas.IsNull() {
+		return nil
+	}
+
+	_, err := c.GetDeploymentSchedule(state.ID.ValueString(), state.ProjectID.ValueString())
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	deployment.MinReplicas = state.MinReplicas.ValueInt64()
+	deployment.MaxReplicas = state.MaxReplicas.ValueInt64()
+	return nil
+}
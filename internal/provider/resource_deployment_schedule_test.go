@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestKeepScheduledReplicaBounds(t *testing.T) {
+	tests := map[string]struct {
+		status      int
+		body        string
+		minReplicas types.Int64
+		maxReplicas types.Int64
+		wantMin     int64
+		wantMax     int64
+		wantErr     bool
+	}{
+		"schedule attached": {
+			status:      http.StatusOK,
+			body:        `{"status":"success","body":{"time_zone":"UTC","rules":[{"cron":"0 20 * * *","min_replicas":0,"max_replicas":0}]}}`,
+			minReplicas: types.Int64Value(1),
+			maxReplicas: types.Int64Value(3),
+			wantMin:     1,
+			wantMax:     3,
+		},
+		"no schedule": {
+			status:      http.StatusOK,
+			body:        `{"status":"success","body":null}`,
+			minReplicas: types.Int64Value(1),
+			maxReplicas: types.Int64Value(3),
+			wantMin:     0,
+			wantMax:     0,
+		},
+		"schedule not found": {
+			status:      http.StatusNotFound,
+			body:        `{"status":"error"}`,
+			minReplicas: types.Int64Value(1),
+			maxReplicas: types.Int64Value(3),
+			wantMin:     0,
+			wantMax:     0,
+		},
+		"imported with schedule attached": {
+			status:      http.StatusOK,
+			body:        `{"status":"success","body":{"time_zone":"UTC","rules":[{"cron":"0 20 * * *","min_replicas":0,"max_replicas":0}]}}`,
+			minReplicas: types.Int64Null(),
+			maxReplicas: types.Int64Null(),
+			wantMin:     0,
+			wantMax:     0,
+		},
+		"server error": {
+			status:      http.StatusInternalServerError,
+			body:        `{"status":"error"}`,
+			minReplicas: types.Int64Value(1),
+			maxReplicas: types.Int64Value(3),
+			wantErr:     true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/deployment/abc123/schedule" || r.URL.Query().Get("project_id") != "prj_1" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := &Client{baseControllerURL: server.URL, httpClient: server.Client()}
+			// the controller scaled the deployment down to zero replicas
+			deployment := &Deployment{ID: "abc123", ProjectID: "prj_1"}
+			state := DeploymentTerraformState{
+				ID:          types.StringValue("abc123"),
+				ProjectID:   types.StringValue("prj_1"),
+				MinReplicas: tt.minReplicas,
+				MaxReplicas: tt.maxReplicas,
+			}
+
+			err := keepScheduledReplicaBounds(client, deployment, state)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if deployment.MinReplicas != tt.wantMin || deployment.MaxReplicas != tt.wantMax {
+				t.Errorf("got replicas %d-%d, want %d-%d", deployment.MinReplicas, deployment.MaxReplicas, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var deploymentReplicaAttrTypes = map[string]attr.Type{
+	"name":          types.StringType,
+	"status":        types.StringType,
+	"ready":         types.BoolType,
+	"restart_count": types.Int64Type,
+	"started_at":    types.StringType,
+}
+
+// setDeploymentStatusState copies the health of the deployment reported by the controller into state
+func setDeploymentStatusState(state *DeploymentTerraformState, deployment *Deployment) {
+	state.Status = stringValueOrNull(deployment.Status, types.StringNull())
+	state.RunningReplicas = types.Int64Value(deployment.RunningReplicas)
+	state.CreatedAt = stringValueOrNull(deployment.CreatedAt, types.StringNull())
+	state.MachineTypeDetails = mapValueFromStrings(deployment.MachineTypeDetails, types.MapNull(types.StringType))
+	state.Replicas = deploymentReplicasValue(deployment.Replicas)
+}
+
+// carryDeploymentStatusState keeps the last known health of the deployment until it's read again
+func carryDeploymentStatusState(state *DeploymentTerraformState, prior DeploymentTerraformState) {
+	state.Status = prior.Status
+	state.RunningReplicas = prior.RunningReplicas
+	state.CreatedAt = prior.CreatedAt
+	state.MachineTypeDetails = prior.MachineTypeDetails
+	state.Replicas = prior.Replicas
+}
+
+// refreshDeploymentStatus reads the current health of the deployment. It's best effort, since a deployment
+// which was just created or updated may not be listed yet, in which case the state is left as it is.
+func (r *deploymentResource) refreshDeploymentStatus(state *DeploymentTerraformState) {
+	deployment, err := r.client.GetDeploymentByID(state.ID.ValueString(), state.ProjectID.ValueString())
+	if err != nil {
+		log.Printf("DEBUG: Unable to read status of deployment %s: %v", state.ID.ValueString(), err)
+		return
+	}
+
+	setDeploymentStatusState(state, deployment)
+}
+
+func deploymentReplicasValue(replicas []DeploymentReplica) types.List {
+	elementType := types.ObjectType{AttrTypes: deploymentReplicaAttrTypes}
+
+	elements := make([]attr.Value, len(replicas))
+	for i, replica := range replicas {
+		elements[i] = types.ObjectValueMust(deploymentReplicaAttrTypes, map[string]attr.Value{
+			"name":          types.StringValue(replica.Name),
+			"status":        types.StringValue(replica.Status),
+			"ready":         types.BoolValue(replica.Ready),
+			"restart_count": types.Int64Value(replica.RestartCount),
+			"started_at":    stringValueOrNull(replica.StartedAt, types.StringNull()),
+		})
+	}
+
+	return types.ListValueMust(elementType, elements)
+}
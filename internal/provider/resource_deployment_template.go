@@ -2,12 +2,19 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -16,6 +23,11 @@ type deploymentTemplateResource struct {
 	client *Client
 }
 
+var (
+	_ resource.ResourceWithImportState    = &deploymentTemplateResource{}
+	_ resource.ResourceWithValidateConfig = &deploymentTemplateResource{}
+)
+
 func DeploymentTemplateResource() resource.Resource {
 	return &deploymentTemplateResource{}
 }
@@ -35,6 +47,9 @@ func (r *deploymentTemplateResource) Schema(ctx context.Context, req resource.Sc
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The name of the deployment template",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "The description of the deployment template",
@@ -42,8 +57,11 @@ func (r *deploymentTemplateResource) Schema(ctx context.Context, req resource.Sc
 			},
 			"tags": schema.ListAttribute{
 				ElementType:         types.StringType,
-				MarkdownDescription: "The tags of the deployment template",
+				MarkdownDescription: "The tags of the deployment template, each one of: " + strings.Join(allowedTags, ", "),
 				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(allowedTags...)),
+				},
 			},
 			"category": schema.StringAttribute{
 				MarkdownDescription: "The category of the deployment template",
@@ -57,10 +75,21 @@ func (r *deploymentTemplateResource) Schema(ctx context.Context, req resource.Sc
 				ElementType:         types.StringType,
 				MarkdownDescription: "The container image of the deployment template",
 				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"registry_credential_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of a `theta_registry_credential` to pull private container images with",
+				Optional:            true,
 			},
 			"container_port": schema.Int64Attribute{
 				MarkdownDescription: "The container port of the deployment template",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
 			},
 			"container_args": schema.ListAttribute{
 				ElementType:         types.StringType,
@@ -71,6 +100,22 @@ func (r *deploymentTemplateResource) Schema(ctx context.Context, req resource.Sc
 				ElementType:         types.StringType,
 				MarkdownDescription: "The environment variables of the deployment template",
 				Optional:            true,
+				Sensitive:           true,
+			},
+			"secret_env_vars": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Environment variables holding secrets such as API tokens. They are sent to the controller together with `env_vars`, but their values are never shown in plan output",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"secret_refs": schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Environment variables set from project secrets, mapping the variable name to the name of a " +
+					"`theta_project_secret`. The controller resolves them when deploying, so rotating a secret updates every template using it",
+				Optional: true,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.RegexMatches(secretNameRegexp, "must be the name of a project secret")),
+				},
 			},
 			"require_env_vars": schema.BoolAttribute{
 				MarkdownDescription: "Whether the deployment template requires environment variables",
@@ -79,6 +124,9 @@ func (r *deploymentTemplateResource) Schema(ctx context.Context, req resource.Sc
 			"rank": schema.Int64Attribute{
 				MarkdownDescription: "The rank of the deployment template",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"icon_url": schema.StringAttribute{
 				MarkdownDescription: "The icon URL of the deployment template",
@@ -92,6 +140,10 @@ func (r *deploymentTemplateResource) Schema(ctx context.Context, req resource.Sc
 	}
 }
 
+func (r *deploymentTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateSecretEnvVarKeys(ctx, req.Config)...)
+}
+
 func (r *deploymentTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	log.Println("DEBUG: Resource Configure method called")
 
@@ -115,17 +167,15 @@ func (r *deploymentTemplateResource) Create(ctx context.Context, req resource.Cr
 	log.Println("DEBUG: Entering Create method")
 
 	// Extract the plan
-	var plan DeploymentTemplateRequest
+	var plan TFDeploymentTemplateStateStruct
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		log.Println("DEBUG: Error getting plan:", resp.Diagnostics)
 		return
 	}
-	log.Printf("DEBUG: Plan received: %+v\n", plan)
 
 	// Convert to native plan
 	nativePlan := convertToNativePlan(plan)
-	log.Printf("DEBUG: Native plan: %+v\n", nativePlan)
 
 	// Call the API
 	template, err := r.client.CreateDeploymentTemplate(nativePlan)
@@ -139,7 +189,7 @@ func (r *deploymentTemplateResource) Create(ctx context.Context, req resource.Cr
 	}
 
 	// Set state
-	state := convertToTerraformState(template)
+	state := convertToTerraformState(template, plan)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -152,17 +202,22 @@ func (r *deploymentTemplateResource) Read(ctx context.Context, req resource.Read
 	}
 
 	template, err := r.client.GetDeploymentTemplateByID(state.ProjectID.ValueString(), state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		resp.Diagnostics.AddWarning("Deployment Template Not Found", fmt.Sprintf("The deployment template %s was not found and has been removed from state.", state.ID.ValueString()))
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployment template, got error: %s", err))
 		return
 	}
 
-	newState := convertToTerraformState(template)
+	newState := convertToTerraformState(template, state)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
 func (r *deploymentTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan DeploymentTemplateRequest
+	var plan TFDeploymentTemplateStateStruct
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -183,7 +238,7 @@ func (r *deploymentTemplateResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
-	newState := convertToTerraformState(template)
+	newState := convertToTerraformState(template, plan)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
@@ -196,6 +251,14 @@ func (r *deploymentTemplateResource) Delete(ctx context.Context, req resource.De
 	}
 
 	success, err := r.client.DeleteDeploymentTemplate(state.ID.ValueString(), state.ProjectID.ValueString())
+	if err != nil || !success {
+		// a template which is already gone counts as deleted
+		if _, getErr := r.client.GetDeploymentTemplateByID(state.ProjectID.ValueString(), state.ID.ValueString()); errors.Is(getErr, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete deployment template, got error: %s", err))
 		return
@@ -209,7 +272,38 @@ func (r *deploymentTemplateResource) Delete(ctx context.Context, req resource.De
 	resp.State.RemoveResource(ctx)
 }
 
-func convertToNativePlan(plan DeploymentTemplateRequest) DeploymentTemplateRequestNative {
+// ImportState accepts either <project_id>/<template_id> or <project_id>/<template_name>
+func (r *deploymentTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, identifier, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || identifier == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <project_id>/<template_id> or <project_id>/<template_name>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	template, err := r.client.GetDeploymentTemplateByID(projectID, identifier)
+	if errors.Is(err, errNotFound) {
+		log.Printf("DEBUG: Template %s not found by ID, trying by name\n", identifier)
+		template, err = r.client.GetDeploymentTemplateByName(projectID, identifier)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import deployment template, got error: %s", err))
+		return
+	}
+
+	// the list endpoint is already scoped to the project, make sure state is too
+	if template.ProjectID == "" {
+		template.ProjectID = projectID
+	}
+
+	// there's no config to compare with yet, so attributes the API returns empty are imported as null
+	state := convertToTerraformState(template, TFDeploymentTemplateStateStruct{})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func convertToNativePlan(plan TFDeploymentTemplateStateStruct) DeploymentTemplateRequestNative {
 	var requireEnvVars *bool
 	if !plan.RequireEnvVars.IsNull() {
 		requireEnvVars = new(bool)
@@ -222,84 +316,113 @@ func convertToNativePlan(plan DeploymentTemplateRequest) DeploymentTemplateReque
 		*rank = plan.Rank.ValueInt64()
 	}
 
+	// the controller only knows about a single set of environment variables
+	var envVars map[string]string
+	if len(plan.EnvVars.Elements()) > 0 || len(plan.SecretEnvVars.Elements()) > 0 {
+		envVars = stringMapFromValue(plan.EnvVars)
+		for k, v := range stringMapFromValue(plan.SecretEnvVars) {
+			envVars[k] = v
+		}
+	}
+
+	var secretRefs map[string]string
+	if len(plan.SecretRefs.Elements()) > 0 {
+		secretRefs = stringMapFromValue(plan.SecretRefs)
+	}
+
 	return DeploymentTemplateRequestNative{
-		Name:           plan.Name,
-		ProjectID:      plan.ProjectID,
-		Description:    plan.Description.ValueString(),
-		ContainerImage: plan.ContainerImages,
-		ContainerPort:  plan.ContainerPort.ValueInt64(),
-		ContainerArgs:  plan.ContainerArgs,
-		EnvVars:        plan.EnvVars,
-		Tags:           plan.Tags,
-		IconURL:        plan.IconURL.ValueString(),
-		RequireEnvVars: requireEnvVars,
-		Rank:           rank,
+		Name:                 plan.Name.ValueString(),
+		ProjectID:            plan.ProjectID.ValueString(),
+		Description:          plan.Description.ValueString(),
+		ContainerImage:       stringSliceFromValue(plan.ContainerImages),
+		RegistryCredentialID: plan.RegistryCredentialID.ValueString(),
+		ContainerPort:        plan.ContainerPort.ValueInt64(),
+		ContainerArgs:        stringSliceFromValue(plan.ContainerArgs),
+		EnvVars:              envVars,
+		SecretRefs:           secretRefs,
+		Tags:                 stringSliceFromValue(plan.Tags),
+		IconURL:              plan.IconURL.ValueString(),
+		RequireEnvVars:       requireEnvVars,
+		Rank:                 rank,
 	}
 }
 
-type TFDeploymentTemplateStateStruct = struct {
-	ID              types.String            `tfsdk:"id"`
-	Name            types.String            `tfsdk:"name"`
-	Description     types.String            `tfsdk:"description"`
-	Tags            []types.String          `tfsdk:"tags"`
-	Category        types.String            `tfsdk:"category"`
-	ProjectID       types.String            `tfsdk:"project_id"`
-	ContainerImages []types.String          `tfsdk:"container_images"`
-	ContainerPort   types.Int64             `tfsdk:"container_port"`
-	ContainerArgs   []types.String          `tfsdk:"container_args"`
-	EnvVars         map[string]types.String `tfsdk:"env_vars"`
-	RequireEnvVars  types.Bool              `tfsdk:"require_env_vars"`
-	Rank            types.Int64             `tfsdk:"rank"`
-	IconURL         types.String            `tfsdk:"icon_url"`
-	CreateTime      types.String            `tfsdk:"create_time"`
+type TFDeploymentTemplateStateStruct struct {
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Description          types.String `tfsdk:"description"`
+	Tags                 types.List   `tfsdk:"tags"`
+	Category             types.String `tfsdk:"category"`
+	ProjectID            types.String `tfsdk:"project_id"`
+	ContainerImages      types.List   `tfsdk:"container_images"`
+	RegistryCredentialID types.String `tfsdk:"registry_credential_id"`
+	ContainerPort        types.Int64  `tfsdk:"container_port"`
+	ContainerArgs        types.List   `tfsdk:"container_args"`
+	EnvVars              types.Map    `tfsdk:"env_vars"`
+	SecretEnvVars        types.Map    `tfsdk:"secret_env_vars"`
+	SecretRefs           types.Map    `tfsdk:"secret_refs"`
+	RequireEnvVars       types.Bool   `tfsdk:"require_env_vars"`
+	Rank                 types.Int64  `tfsdk:"rank"`
+	IconURL              types.String `tfsdk:"icon_url"`
+	CreateTime           types.String `tfsdk:"create_time"`
 }
 
-func convertToTerraformState(template *DeploymentTemplate) struct {
-	ID              types.String            `tfsdk:"id"`
-	Name            types.String            `tfsdk:"name"`
-	Description     types.String            `tfsdk:"description"`
-	Tags            []types.String          `tfsdk:"tags"`
-	Category        types.String            `tfsdk:"category"`
-	ProjectID       types.String            `tfsdk:"project_id"`
-	ContainerImages []types.String          `tfsdk:"container_images"`
-	ContainerPort   types.Int64             `tfsdk:"container_port"`
-	ContainerArgs   []types.String          `tfsdk:"container_args"`
-	EnvVars         map[string]types.String `tfsdk:"env_vars"`
-	RequireEnvVars  types.Bool              `tfsdk:"require_env_vars"`
-	Rank            types.Int64             `tfsdk:"rank"`
-	IconURL         types.String            `tfsdk:"icon_url"`
-	CreateTime      types.String            `tfsdk:"create_time"`
-} {
+// convertToTerraformState converts the template returned by the API, using the prior plan or state
+// to tell whether an attribute the API returns empty was left out of the config or set to an empty value
+func convertToTerraformState(template *DeploymentTemplate, prior TFDeploymentTemplateStateStruct) TFDeploymentTemplateStateStruct {
+	envVars, secretEnvVars := splitSecretEnvVars(template.EnvVars, prior)
+
 	return TFDeploymentTemplateStateStruct{
-		ID:              types.StringValue(template.ID),
-		Name:            types.StringValue(template.Name),
-		Description:     types.StringValue(template.Description),
-		Tags:            convertToTypesStringSlice(template.Tags),
-		Category:        types.StringValue(template.Category),
-		ProjectID:       types.StringValue(template.ProjectID),
-		ContainerImages: convertToTypesStringSlice(template.ContainerImages),
-		ContainerPort:   types.Int64Value(template.ContainerPort),
-		ContainerArgs:   convertToTypesStringSlice(template.ContainerArgs),
-		EnvVars:         convertToTypesStringMap(template.EnvVars),
-		RequireEnvVars:  types.BoolNull(),
-		Rank:            types.Int64Null(),
-		IconURL:         types.StringValue(template.IconURL),
-		CreateTime:      types.StringValue(template.CreateTime.Format(time.RFC3339)),
+		ID:                   types.StringValue(template.ID),
+		Name:                 types.StringValue(template.Name),
+		Description:          stringValueOrNull(template.Description, prior.Description),
+		Tags:                 listValueFromStrings(template.Tags, prior.Tags),
+		Category:             types.StringValue(template.Category),
+		ProjectID:            types.StringValue(template.ProjectID),
+		ContainerImages:      listValueFromStrings(template.ContainerImages, prior.ContainerImages),
+		RegistryCredentialID: stringValueOrNull(template.RegistryCredentialID, prior.RegistryCredentialID),
+		ContainerPort:        int64ValueOrNull(template.ContainerPort, prior.ContainerPort),
+		ContainerArgs:        listValueFromStrings(template.ContainerArgs, prior.ContainerArgs),
+		EnvVars:              envVars,
+		SecretEnvVars:        secretEnvVars,
+		SecretRefs:           mapValueFromStrings(template.SecretRefs, prior.SecretRefs),
+		RequireEnvVars:       boolPtrToValue(template.RequireEnvVars, prior.RequireEnvVars),
+		Rank:                 int64PtrToValue(template.Rank, prior.Rank),
+		IconURL:              stringValueOrNull(template.IconURL, prior.IconURL),
+		CreateTime:           types.StringValue(template.CreateTime.Format(time.RFC3339)),
+	}
+}
+
+// splitSecretEnvVars separates the variables configured as secret from the rest,
+// since the controller returns all environment variables in a single map
+func splitSecretEnvVars(input map[string]string, prior TFDeploymentTemplateStateStruct) (types.Map, types.Map) {
+	secretKeys := prior.SecretEnvVars.Elements()
+
+	envVars := make(map[string]string, len(input))
+	secretEnvVars := make(map[string]string, len(secretKeys))
+	for k, v := range input {
+		if _, ok := secretKeys[k]; ok {
+			secretEnvVars[k] = v
+			continue
+		}
+		envVars[k] = v
 	}
+
+	return mapValueFromStrings(envVars, prior.EnvVars), mapValueFromStrings(secretEnvVars, prior.SecretEnvVars)
 }
 
-func convertToTypesStringSlice(input []string) []types.String {
-	result := make([]types.String, len(input))
-	for i, v := range input {
-		result[i] = types.StringValue(v)
+// boolPtrToValue keeps the attribute null when the API leaves it out, or returns false for it and it was null before
+func boolPtrToValue(ptr *bool, prior types.Bool) types.Bool {
+	if ptr == nil || (!*ptr && prior.IsNull()) {
+		return types.BoolNull()
 	}
-	return result
+	return types.BoolValue(*ptr)
 }
 
-func convertToTypesStringMap(input map[string]string) map[string]types.String {
-	result := make(map[string]types.String, len(input))
-	for k, v := range input {
-		result[k] = types.StringValue(v)
+// int64PtrToValue keeps the attribute null when the API leaves it out, or returns 0 for it and it was null before
+func int64PtrToValue(ptr *int64, prior types.Int64) types.Int64 {
+	if ptr == nil {
+		return types.Int64Null()
 	}
-	return result
+	return int64ValueOrNull(*ptr, prior)
 }
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var deploymentVolumeMountAttrTypes = map[string]attr.Type{
+	"volume_id":  types.StringType,
+	"mount_path": types.StringType,
+	"read_only":  types.BoolType,
+}
+
+// deploymentVolumeMountsFromPlan returns the mounts sent to the controller, an empty list detaches all volumes
+func deploymentVolumeMountsFromPlan(list types.List) []DeploymentVolumeMount {
+	mounts := []DeploymentVolumeMount{}
+	for _, element := range list.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+
+		attributes := object.Attributes()
+		volumeID, _ := attributes["volume_id"].(types.String)
+		mountPath, _ := attributes["mount_path"].(types.String)
+		readOnly, _ := attributes["read_only"].(types.Bool)
+		mounts = append(mounts, DeploymentVolumeMount{
+			VolumeID:  volumeID.ValueString(),
+			MountPath: mountPath.ValueString(),
+			ReadOnly:  readOnly.ValueBool(),
+		})
+	}
+	return mounts
+}
+
+// deploymentVolumeMountsValue builds the volume_mounts attribute, which stays null when nothing is mounted
+// and the attribute was null before
+func deploymentVolumeMountsValue(mounts []DeploymentVolumeMount, prior types.List) types.List {
+	elementType := types.ObjectType{AttrTypes: deploymentVolumeMountAttrTypes}
+	if len(mounts) == 0 && prior.IsNull() {
+		return types.ListNull(elementType)
+	}
+
+	elements := make([]attr.Value, len(mounts))
+	for i, mount := range mounts {
+		elements[i] = types.ObjectValueMust(deploymentVolumeMountAttrTypes, map[string]attr.Value{
+			"volume_id":  types.StringValue(mount.VolumeID),
+			"mount_path": types.StringValue(mount.MountPath),
+			"read_only":  types.BoolValue(mount.ReadOnly),
+		})
+	}
+	return types.ListValueMust(elementType, elements)
+}
@@ -2,11 +2,19 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -148,3 +156,213 @@ func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRe
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
+
+// Resource for organizations
+type organizationResource struct {
+	client *Client
+}
+
+var _ resource.ResourceWithImportState = &organizationResource{}
+
+func OrganizationResource() resource.Resource {
+	return &organizationResource{}
+}
+
+type OrganizationState struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Email      types.String `tfsdk:"email"`
+	LogoURL    types.String `tfsdk:"logo_url"`
+	CreateTime types.String `tfsdk:"create_time"`
+	UserRole   types.String `tfsdk:"user_role"`
+	Disabled   types.Bool   `tfsdk:"disabled"`
+	Suspended  types.Bool   `tfsdk:"suspended"`
+}
+
+func (r *organizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_organization"
+}
+
+func (r *organizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceschema.Schema{
+		MarkdownDescription: "Resource for managing Theta organizations. Organizations can't be deleted through the API, " +
+			"destroying the resource only stops Terraform from managing the organization",
+		Attributes: map[string]resourceschema.Attribute{
+			"id": resourceschema.StringAttribute{
+				MarkdownDescription: "The ID of the organization",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": resourceschema.StringAttribute{
+				MarkdownDescription: "The name of the organization",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"email": resourceschema.StringAttribute{
+				MarkdownDescription: "The email associated with the organization. Defaults to the email of the authenticated user",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(emailRegexp, "must be an email address"),
+				},
+			},
+			"logo_url": resourceschema.StringAttribute{
+				MarkdownDescription: "The logo URL of the organization",
+				Optional:            true,
+			},
+			"create_time": resourceschema.StringAttribute{
+				MarkdownDescription: "The creation time of the organization",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_role": resourceschema.StringAttribute{
+				MarkdownDescription: "The authenticated user's role in the organization",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"disabled": resourceschema.BoolAttribute{
+				MarkdownDescription: "Whether the organization is disabled",
+				Computed:            true,
+			},
+			"suspended": resourceschema.BoolAttribute{
+				MarkdownDescription: "Whether the organization is suspended",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *organizationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *organizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan OrganizationState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organization, err := r.client.CreateOrganization(convertToOrganizationRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create organization, got error: %s", err))
+		return
+	}
+
+	state := convertToOrganizationState(organization, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state OrganizationState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organization, err := r.client.GetOrganization(state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Organization Not Found", "The organization was not found or the authenticated user is no longer a member of it.")
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization, got error: %s", err))
+		}
+		return
+	}
+
+	newState := convertToOrganizationState(organization, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state OrganizationState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organization, err := r.client.UpdateOrganization(state.ID.ValueString(), convertToOrganizationRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update organization, got error: %s", err))
+		return
+	}
+
+	// the update response doesn't necessarily hold the whole organization
+	if organization.ID == "" {
+		organization, err = r.client.GetOrganization(state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated organization, got error: %s", err))
+			return
+		}
+	}
+
+	newState := convertToOrganizationState(organization, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state OrganizationState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Organization Not Deleted",
+		fmt.Sprintf("Organizations can't be deleted through the API, organization %s was only removed from the Terraform state. Delete it in the console if needed.", state.ID.ValueString()),
+	)
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *organizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func convertToOrganizationRequest(plan OrganizationState) OrganizationRequest {
+	return OrganizationRequest{
+		Name:    plan.Name.ValueString(),
+		Email:   plan.Email.ValueString(),
+		LogoURL: plan.LogoURL.ValueString(),
+	}
+}
+
+func convertToOrganizationState(organization *Organization, prior OrganizationState) OrganizationState {
+	return OrganizationState{
+		ID:         types.StringValue(organization.ID),
+		Name:       types.StringValue(organization.Name),
+		Email:      types.StringValue(organization.Email),
+		LogoURL:    stringValueOrNull(organization.LogoURL, prior.LogoURL),
+		CreateTime: types.StringValue(organization.CreateTime),
+		UserRole:   types.StringValue(organization.UserRole),
+		Disabled:   types.BoolValue(organization.Disabled),
+		Suspended:  types.BoolValue(organization.Suspended),
+	}
+}
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource for organization invitations
+type organizationInvitationResource struct {
+	client *Client
+}
+
+var _ resource.ResourceWithImportState = &organizationInvitationResource{}
+
+func OrganizationInvitationResource() resource.Resource {
+	return &organizationInvitationResource{}
+}
+
+type OrganizationInvitationState struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Email          types.String `tfsdk:"email"`
+	Role           types.String `tfsdk:"role"`
+	Status         types.String `tfsdk:"status"`
+	CreateTime     types.String `tfsdk:"create_time"`
+}
+
+func (r *organizationInvitationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_organization_invitation"
+}
+
+func (r *organizationInvitationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for inviting someone to a Theta organization. Once the invitation is accepted its status " +
+			"changes to `accepted`, manage the role of the new member with `theta_organization_member` from then on",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the invitation",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email address the invitation is sent to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(emailRegexp, "must be an email address"),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role the user gets when accepting the invitation, one of: " + strings.Join(memberRoles, ", "),
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(memberRoles...),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Whether the invitation is `pending` or `accepted`",
+				Computed:            true,
+			},
+			"create_time": schema.StringAttribute{
+				MarkdownDescription: "When the invitation was sent",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *organizationInvitationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *organizationInvitationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan OrganizationInvitationState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invitation, err := r.client.CreateOrganizationInvitation(plan.OrganizationID.ValueString(), OrganizationInvitationRequest{
+		Email: plan.Email.ValueString(),
+		Role:  plan.Role.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create organization invitation, got error: %s", err))
+		return
+	}
+
+	state := convertToOrganizationInvitationState(plan.OrganizationID.ValueString(), invitation, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationInvitationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state OrganizationInvitationState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID := state.OrganizationID.ValueString()
+
+	invitation, err := r.client.GetOrganizationInvitation(orgID, state.ID.ValueString())
+	if err == nil {
+		newState := convertToOrganizationInvitationState(orgID, invitation, state)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+		return
+	}
+	if !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization invitation, got error: %s", err))
+		return
+	}
+
+	// accepted invitations are no longer listed, the invited user shows up as a member instead
+	_, err = r.client.FindOrganizationMember(orgID, "", state.Email.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Organization Invitation Not Found", "The invitation was withdrawn, declined or has expired and will be sent again.")
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization invitation, got error: %s", err))
+		}
+		return
+	}
+
+	state.Status = types.StringValue(invitationStatusAccepted)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationInvitationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// every attribute either forces a new invitation or is computed, so there's nothing to update in place
+	var plan OrganizationInvitationState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state OrganizationInvitationState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationInvitationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state OrganizationInvitationState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the membership created by accepting the invitation is managed by theta_organization_member
+	if state.Status.ValueString() == invitationStatusAccepted {
+		resp.Diagnostics.AddWarning(
+			"Organization Invitation Already Accepted",
+			fmt.Sprintf("%s already joined the organization and stays a member, use theta_organization_member to remove them.", state.Email.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// an invitation which is already gone counts as withdrawn
+	err := r.client.DeleteOrganizationInvitation(state.OrganizationID.ValueString(), state.ID.ValueString())
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete organization invitation, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <organization_id>/<invitation_id>
+func (r *organizationInvitationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, invitationID, ok := strings.Cut(req.ID, "/")
+	if !ok || orgID == "" || invitationID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <organization_id>/<invitation_id>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	invitation, err := r.client.GetOrganizationInvitation(orgID, invitationID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import organization invitation, got error: %s", err))
+		return
+	}
+
+	state := convertToOrganizationInvitationState(orgID, invitation, OrganizationInvitationState{})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func convertToOrganizationInvitationState(orgID string, invitation *OrganizationInvitation, prior OrganizationInvitationState) OrganizationInvitationState {
+	email := types.StringValue(invitation.Email)
+	if invitation.Email == "" || (!prior.Email.IsNull() && strings.EqualFold(invitation.Email, prior.Email.ValueString())) {
+		email = prior.Email
+	}
+
+	role := types.StringValue(invitation.Role)
+	if invitation.Role == "" {
+		role = prior.Role
+	}
+
+	status := invitation.Status
+	if status == "" {
+		status = invitationStatusPending
+	}
+
+	return OrganizationInvitationState{
+		ID:             types.StringValue(invitation.ID),
+		OrganizationID: types.StringValue(orgID),
+		Email:          email,
+		Role:           role,
+		Status:         types.StringValue(strings.ToLower(status)),
+		CreateTime:     types.StringValue(invitation.CreateTime),
+	}
+}
@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Invited users can take a while to accept, so creating a member waits for them by default
+const defaultOrganizationMemberCreateTimeout = 24 * time.Hour
+
+// Resource for organization members
+type organizationMemberResource struct {
+	client *Client
+}
+
+var (
+	_ resource.ResourceWithImportState      = &organizationMemberResource{}
+	_ resource.ResourceWithConfigValidators = &organizationMemberResource{}
+)
+
+func OrganizationMemberResource() resource.Resource {
+	return &organizationMemberResource{}
+}
+
+type OrganizationMemberState struct {
+	ID             types.String   `tfsdk:"id"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	UserID         types.String   `tfsdk:"user_id"`
+	Email          types.String   `tfsdk:"email"`
+	Role           types.String   `tfsdk:"role"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *organizationMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_organization_member"
+}
+
+func (r *organizationMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for managing the role of a Theta organization member. Users join an organization by accepting a " +
+			"`theta_organization_invitation`, so creating this resource waits until the user has accepted their invitation, up to the " +
+			"create timeout. Destroying this resource removes the user from the organization",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the membership, in the format `<organization_id>/<user_id>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user. Either `user_id` or `email` has to be set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email address of the user. Either `user_id` or `email` has to be set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role of the user in the organization, one of: " + strings.Join(memberRoles, ", "),
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(memberRoles...),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *organizationMemberResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("user_id"), path.MatchRoot("email")),
+	}
+}
+
+func (r *organizationMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *organizationMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan OrganizationMemberState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOrganizationMemberCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// users join by accepting their invitation, so the resource waits for that before it takes over managing their role
+	member, err := r.client.WaitForOrganizationMember(ctx, plan.OrganizationID.ValueString(), plan.UserID.ValueString(), plan.Email.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization member, got error: %s", err))
+		return
+	}
+
+	if member.Role != plan.Role.ValueString() {
+		updated, err := r.client.UpdateOrganizationMemberRole(plan.OrganizationID.ValueString(), member.UserID, plan.Role.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update organization member, got error: %s", err))
+			return
+		}
+		member.Role = plan.Role.ValueString()
+		if updated.Role != "" {
+			member.Role = updated.Role
+		}
+	}
+
+	state := convertToOrganizationMemberState(plan.OrganizationID.ValueString(), member, plan)
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state OrganizationMemberState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := r.client.FindOrganizationMember(state.OrganizationID.ValueString(), state.UserID.ValueString(), "")
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Organization Member Not Found", "The user is no longer a member of the organization.")
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization member, got error: %s", err))
+		}
+		return
+	}
+
+	newState := convertToOrganizationMemberState(state.OrganizationID.ValueString(), member, state)
+	newState.Timeouts = state.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *organizationMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state OrganizationMemberState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the role can change, anything else replaces the membership
+	_, err := r.client.UpdateOrganizationMemberRole(state.OrganizationID.ValueString(), state.UserID.ValueString(), plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update organization member, got error: %s", err))
+		return
+	}
+
+	state.Role = plan.Role
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *organizationMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state OrganizationMemberState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a user who already left the organization counts as removed
+	err := r.client.RemoveOrganizationMember(state.OrganizationID.ValueString(), state.UserID.ValueString())
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove organization member, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts either <organization_id>/<user_id> or <organization_id>/<email>
+func (r *organizationMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, identifier, ok := strings.Cut(req.ID, "/")
+	if !ok || orgID == "" || identifier == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <organization_id>/<user_id> or <organization_id>/<email>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	userID, email := identifier, ""
+	if strings.Contains(identifier, "@") {
+		userID, email = "", identifier
+	}
+
+	member, err := r.client.FindOrganizationMember(orgID, userID, email)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import organization member, got error: %s", err))
+		return
+	}
+
+	// Read fills in the rest, leaving the timeouts block null
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), orgID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), member.UserID)...)
+}
+
+// convertToOrganizationMemberState keeps the configured spelling of the email, which the controller may normalize
+func convertToOrganizationMemberState(orgID string, member *OrganizationMember, prior OrganizationMemberState) OrganizationMemberState {
+	email := types.StringValue(member.Email)
+	if !prior.Email.IsNull() && !prior.Email.IsUnknown() && strings.EqualFold(member.Email, prior.Email.ValueString()) {
+		email = prior.Email
+	}
+
+	return OrganizationMemberState{
+		ID:             types.StringValue(fmt.Sprintf("%s/%s", orgID, member.UserID)),
+		OrganizationID: types.StringValue(orgID),
+		UserID:         types.StringValue(member.UserID),
+		Email:          email,
+		Role:           types.StringValue(member.Role),
+	}
+}
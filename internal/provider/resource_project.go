@@ -74,14 +74,17 @@ func (d *projectDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 						"tva_secret": schema.StringAttribute{
 							MarkdownDescription: "The TVA secret of the project",
 							Computed:            true,
+							Sensitive:           true,
 						},
 						"gateway_key": schema.StringAttribute{
 							MarkdownDescription: "The gateway key of the project",
 							Computed:            true,
+							Sensitive:           true,
 						},
 						"gateway_secret": schema.StringAttribute{
 							MarkdownDescription: "The gateway secret of the project",
 							Computed:            true,
+							Sensitive:           true,
 						},
 						"disabled": schema.BoolAttribute{
 							MarkdownDescription: "Whether the project is disabled",
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource for the API gateway of a project
+type projectGatewayResource struct {
+	client *Client
+}
+
+var (
+	_ resource.ResourceWithImportState = &projectGatewayResource{}
+	_ resource.ResourceWithModifyPlan  = &projectGatewayResource{}
+)
+
+func ProjectGatewayResource() resource.Resource {
+	return &projectGatewayResource{}
+}
+
+type ProjectGatewayState struct {
+	ID              types.String `tfsdk:"id"`
+	ProjectID       types.String `tfsdk:"project_id"`
+	GatewayKey      types.String `tfsdk:"gateway_key"`
+	GatewaySecret   types.String `tfsdk:"gateway_secret"`
+	RotationTrigger types.String `tfsdk:"rotation_trigger"`
+}
+
+func (r *projectGatewayResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_project_gateway"
+}
+
+func (r *projectGatewayResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for enabling the API gateway of a Theta project. Destroying the resource disables the gateway",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the gateway",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gateway_key": schema.StringAttribute{
+				MarkdownDescription: "The key of the gateway",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"gateway_secret": schema.StringAttribute{
+				MarkdownDescription: "The secret of the gateway",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_trigger": schema.StringAttribute{
+				MarkdownDescription: "Any value, changing it to a new value rotates the gateway secret, for example a date to rotate on a schedule. Removing it keeps the current secret",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// ModifyPlan shows the secret as changing when the rotation trigger changes to a new value
+func (r *projectGatewayResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planTrigger, stateTrigger types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotation_trigger"), &planTrigger)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("rotation_trigger"), &stateTrigger)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rotatesGatewaySecret(planTrigger, stateTrigger) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("gateway_secret"), types.StringUnknown())...)
+	}
+}
+
+// rotatesGatewaySecret is true when the trigger changes to a new value, removing the trigger keeps the secret
+func rotatesGatewaySecret(planTrigger, stateTrigger types.String) bool {
+	return !planTrigger.IsNull() && !planTrigger.Equal(stateTrigger)
+}
+
+func (r *projectGatewayResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *projectGatewayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ProjectGatewayState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := plan.ProjectID.ValueString()
+
+	// a gateway enabled in the console is taken over as it is
+	project, err := r.client.GetProject(projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project, got error: %s", err))
+		return
+	}
+
+	if project.GatewayID == nil {
+		project, err = r.client.EnableProjectGateway(projectID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to enable project gateway, got error: %s", err))
+			return
+		}
+	}
+
+	state, err := r.convertToProjectGatewayState(projectID, project, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project gateway, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *projectGatewayResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ProjectGatewayState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := r.client.GetProject(state.ProjectID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Project Not Found", "The project of the gateway was not found.")
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project, got error: %s", err))
+		}
+		return
+	}
+
+	if project.GatewayID == nil {
+		resp.State.RemoveResource(ctx)
+		resp.Diagnostics.AddWarning("Project Gateway Disabled", "The gateway of the project was disabled outside of Terraform and will be enabled again.")
+		return
+	}
+
+	newState, err := r.convertToProjectGatewayState(state.ProjectID.ValueString(), project, state)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project gateway, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *projectGatewayResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ProjectGatewayState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the rotation trigger is the only attribute which can change in place
+	if !rotatesGatewaySecret(plan.RotationTrigger, state.RotationTrigger) {
+		state.RotationTrigger = plan.RotationTrigger
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	project, err := r.client.RotateProjectGatewaySecret(state.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rotate project gateway secret, got error: %s", err))
+		return
+	}
+
+	newState, err := r.convertToProjectGatewayState(state.ProjectID.ValueString(), project, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project gateway, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *projectGatewayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ProjectGatewayState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a gateway of a project which is gone counts as disabled
+	err := r.client.DisableProjectGateway(state.ProjectID.ValueString())
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disable project gateway, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts the project ID
+func (r *projectGatewayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("project_id"), req, resp)
+}
+
+// convertToProjectGatewayState reads the project again when the gateway response doesn't include the credentials
+func (r *projectGatewayResource) convertToProjectGatewayState(projectID string, project *Project, prior ProjectGatewayState) (ProjectGatewayState, error) {
+	if project.GatewayID == nil || project.GatewayKey == nil || project.GatewaySecret == nil {
+		var err error
+		project, err = r.client.GetProject(projectID)
+		if err != nil {
+			return ProjectGatewayState{}, err
+		}
+		if project.GatewayID == nil {
+			return ProjectGatewayState{}, fmt.Errorf("gateway of project %s is not enabled", projectID)
+		}
+	}
+
+	return ProjectGatewayState{
+		ID:              stringPtrToValue(project.GatewayID),
+		ProjectID:       types.StringValue(projectID),
+		GatewayKey:      stringPtrToValue(project.GatewayKey),
+		GatewaySecret:   stringPtrToValue(project.GatewaySecret),
+		RotationTrigger: prior.RotationTrigger,
+	}, nil
+}
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource for project members
+type projectMemberResource struct {
+	client *Client
+}
+
+var (
+	_ resource.ResourceWithImportState      = &projectMemberResource{}
+	_ resource.ResourceWithConfigValidators = &projectMemberResource{}
+)
+
+func ProjectMemberResource() resource.Resource {
+	return &projectMemberResource{}
+}
+
+type ProjectMemberState struct {
+	ID        types.String `tfsdk:"id"`
+	ProjectID types.String `tfsdk:"project_id"`
+	UserID    types.String `tfsdk:"user_id"`
+	Email     types.String `tfsdk:"email"`
+	Role      types.String `tfsdk:"role"`
+}
+
+func (r *projectMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_project_member"
+}
+
+func (r *projectMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for managing the members of a Theta project and their roles",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the membership, in the format `<project_id>/<user_id>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user. Either `user_id` or `email` has to be set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email address of the user. Either `user_id` or `email` has to be set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role of the user in the project, one of: " + strings.Join(memberRoles, ", "),
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(memberRoles...),
+				},
+			},
+		},
+	}
+}
+
+func (r *projectMemberResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("user_id"), path.MatchRoot("email")),
+	}
+}
+
+func (r *projectMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *projectMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ProjectMemberState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := r.client.AddProjectMember(plan.ProjectID.ValueString(), ProjectMemberRequest{
+		UserID: plan.UserID.ValueString(),
+		Email:  plan.Email.ValueString(),
+		Role:   plan.Role.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add project member, got error: %s", err))
+		return
+	}
+
+	// the controller doesn't always return the user it added, so look it up among the members
+	if member.UserID == "" {
+		member, err = r.client.FindProjectMember(plan.ProjectID.ValueString(), plan.UserID.ValueString(), plan.Email.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read added project member, got error: %s", err))
+			return
+		}
+	}
+
+	state := convertToProjectMemberState(plan.ProjectID.ValueString(), member, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *projectMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ProjectMemberState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := r.client.GetProjectMember(state.ProjectID.ValueString(), state.UserID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Project Member Not Found", "The user is no longer a member of the project and will be added again.")
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project member, got error: %s", err))
+		}
+		return
+	}
+
+	newState := convertToProjectMemberState(state.ProjectID.ValueString(), member, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *projectMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ProjectMemberState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the role can change, anything else replaces the membership
+	member, err := r.client.UpdateProjectMemberRole(state.ProjectID.ValueString(), state.UserID.ValueString(), plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update project member, got error: %s", err))
+		return
+	}
+
+	if member.UserID == "" {
+		member = &ProjectMember{UserID: state.UserID.ValueString(), Email: state.Email.ValueString(), Role: plan.Role.ValueString()}
+	}
+
+	newState := convertToProjectMemberState(state.ProjectID.ValueString(), member, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *projectMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ProjectMemberState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a user who already left the project counts as removed
+	err := r.client.RemoveProjectMember(state.ProjectID.ValueString(), state.UserID.ValueString())
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove project member, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts either <project_id>/<user_id> or <project_id>/<email>
+func (r *projectMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, identifier, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || identifier == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <project_id>/<user_id> or <project_id>/<email>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	userID, email := identifier, ""
+	if strings.Contains(identifier, "@") {
+		userID, email = "", identifier
+	}
+
+	member, err := r.client.FindProjectMember(projectID, userID, email)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import project member, got error: %s", err))
+		return
+	}
+
+	state := convertToProjectMemberState(projectID, member, ProjectMemberState{})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// convertToProjectMemberState keeps the configured spelling of the email, which the controller may normalize
+func convertToProjectMemberState(projectID string, member *ProjectMember, prior ProjectMemberState) ProjectMemberState {
+	email := types.StringValue(member.Email)
+	if !prior.Email.IsNull() && !prior.Email.IsUnknown() && strings.EqualFold(member.Email, prior.Email.ValueString()) {
+		email = prior.Email
+	}
+
+	return ProjectMemberState{
+		ID:        types.StringValue(fmt.Sprintf("%s/%s", projectID, member.UserID)),
+		ProjectID: types.StringValue(projectID),
+		UserID:    types.StringValue(member.UserID),
+		Email:     email,
+		Role:      types.StringValue(member.Role),
+	}
+}
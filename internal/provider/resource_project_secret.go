@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource for project secrets, referenced by name from the secret_refs of templates and deployments
+type projectSecretResource struct {
+	client *Client
+}
+
+var _ resource.ResourceWithImportState = &projectSecretResource{}
+
+func ProjectSecretResource() resource.Resource {
+	return &projectSecretResource{}
+}
+
+type ProjectSecretState struct {
+	ID         types.String `tfsdk:"id"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	Name       types.String `tfsdk:"name"`
+	Value      types.String `tfsdk:"value"`
+	CreateTime types.String `tfsdk:"create_time"`
+	UpdateTime types.String `tfsdk:"update_time"`
+}
+
+func (r *projectSecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_project_secret"
+}
+
+func (r *projectSecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for a named secret stored in a Theta project. Templates and deployments reference it by name " +
+			"through `secret_refs`, so the value is kept out of their definitions. The API never returns the value, " +
+			"changes made outside of Terraform are not detected",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the secret, in the format `project_id/name`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the secret. Letters, digits and underscores only, not starting with a digit",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(secretNameRegexp, "must contain only letters, digits and underscores, and not start with a digit"),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the secret",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"create_time": schema.StringAttribute{
+				MarkdownDescription: "The time the secret was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"update_time": schema.StringAttribute{
+				MarkdownDescription: "The time the value of the secret was last changed",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *projectSecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *projectSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ProjectSecretState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, err := r.client.CreateProjectSecret(plan.ProjectID.ValueString(), ProjectSecretRequest{
+		Name:  plan.Name.ValueString(),
+		Value: plan.Value.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create project secret, got error: %s", err))
+		return
+	}
+
+	state := convertToProjectSecretState(secret, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *projectSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ProjectSecretState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, err := r.client.GetProjectSecret(state.ProjectID.ValueString(), state.Name.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Project Secret Not Found", fmt.Sprintf("Secret %s was not found and will be created again.", state.Name.ValueString()))
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project secret, got error: %s", err))
+		}
+		return
+	}
+
+	newState := convertToProjectSecretState(secret, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *projectSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ProjectSecretState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the value is the only attribute which can change in place
+	secret, err := r.client.UpdateProjectSecret(plan.ProjectID.ValueString(), plan.Name.ValueString(), plan.Value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update project secret, got error: %s", err))
+		return
+	}
+
+	state := convertToProjectSecretState(secret, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *projectSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ProjectSecretState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteProjectSecret(state.ProjectID.ValueString(), state.Name.ValueString())
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete project secret, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <project_id>/<name>. The value can't be read back, so it's set by the next apply.
+func (r *projectSecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id/name. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+func convertToProjectSecretState(secret *ProjectSecret, prior ProjectSecretState) ProjectSecretState {
+	return ProjectSecretState{
+		ID:         types.StringValue(fmt.Sprintf("%s/%s", prior.ProjectID.ValueString(), secret.Name)),
+		ProjectID:  prior.ProjectID,
+		Name:       types.StringValue(secret.Name),
+		Value:      prior.Value,
+		CreateTime: types.StringValue(secret.CreateTime),
+		UpdateTime: types.StringValue(secret.UpdateTime),
+	}
+}
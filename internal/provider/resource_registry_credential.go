@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource for private container registry credentials, referenced by templates and deployments through registry_credential_id
+type registryCredentialResource struct {
+	client *Client
+}
+
+var _ resource.ResourceWithImportState = &registryCredentialResource{}
+
+func RegistryCredentialResource() resource.Resource {
+	return &registryCredentialResource{}
+}
+
+type RegistryCredentialState struct {
+	ID         types.String `tfsdk:"id"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	Registry   types.String `tfsdk:"registry"`
+	Username   types.String `tfsdk:"username"`
+	Password   types.String `tfsdk:"password"`
+	CreateTime types.String `tfsdk:"create_time"`
+}
+
+func (r *registryCredentialResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_registry_credential"
+}
+
+func (r *registryCredentialResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for the credentials of a private container registry, used to pull the images of templates " +
+			"and deployments referencing it with `registry_credential_id`. The API never returns the password, " +
+			"changes made outside of Terraform are not detected",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the registry credential",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"registry": schema.StringAttribute{
+				MarkdownDescription: "The host of the registry, with an optional port, for example `ghcr.io` or `registry.example.com:5000`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(registryHostRegexp, "must be a host name with an optional port, without a scheme or path"),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The username to log in to the registry with",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password or access token to log in to the registry with",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"create_time": schema.StringAttribute{
+				MarkdownDescription: "The time the registry credential was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *registryCredentialResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *registryCredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RegistryCredentialState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credential, err := r.client.CreateRegistryCredential(convertToRegistryCredentialRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create registry credential, got error: %s", err))
+		return
+	}
+
+	state := convertToRegistryCredentialState(credential, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *registryCredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RegistryCredentialState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credential, err := r.client.GetRegistryCredential(state.ID.ValueString(), state.ProjectID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Registry Credential Not Found", fmt.Sprintf("Registry credential %s was not found and will be created again.", state.ID.ValueString()))
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read registry credential, got error: %s", err))
+		}
+		return
+	}
+
+	newState := convertToRegistryCredentialState(credential, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *registryCredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state RegistryCredentialState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credential, err := r.client.UpdateRegistryCredential(state.ID.ValueString(), convertToRegistryCredentialRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update registry credential, got error: %s", err))
+		return
+	}
+
+	newState := convertToRegistryCredentialState(credential, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *registryCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RegistryCredentialState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRegistryCredential(state.ID.ValueString(), state.ProjectID.ValueString())
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete registry credential, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <project_id>/<registry_credential_id>. The password can't be read back, so it's set by the next apply.
+func (r *registryCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, credentialID, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || credentialID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id/registry_credential_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), credentialID)...)
+}
+
+func convertToRegistryCredentialRequest(plan RegistryCredentialState) RegistryCredentialRequest {
+	return RegistryCredentialRequest{
+		ProjectID: plan.ProjectID.ValueString(),
+		Registry:  plan.Registry.ValueString(),
+		Username:  plan.Username.ValueString(),
+		Password:  plan.Password.ValueString(),
+	}
+}
+
+func convertToRegistryCredentialState(credential *RegistryCredential, prior RegistryCredentialState) RegistryCredentialState {
+	return RegistryCredentialState{
+		ID:         types.StringValue(credential.ID),
+		ProjectID:  prior.ProjectID,
+		Registry:   types.StringValue(credential.Registry),
+		Username:   types.StringValue(credential.Username),
+		Password:   prior.Password,
+		CreateTime: types.StringValue(credential.CreateTime),
+	}
+}
@@ -0,0 +1,470 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Encoding long videos in several resolutions can take a while
+const defaultVideoCreateTimeout = 60 * time.Minute
+
+// Resource for videos uploaded to the Theta Video API, managed with the video credentials of a project
+type videoResource struct {
+	client *Client
+}
+
+var (
+	_ resource.ResourceWithImportState    = &videoResource{}
+	_ resource.ResourceWithModifyPlan     = &videoResource{}
+	_ resource.ResourceWithValidateConfig = &videoResource{}
+)
+
+func VideoResource() resource.Resource {
+	return &videoResource{}
+}
+
+type VideoState struct {
+	ID          types.String     `tfsdk:"id"`
+	ProjectID   types.String     `tfsdk:"project_id"`
+	Name        types.String     `tfsdk:"name"`
+	Source      types.String     `tfsdk:"source"`
+	SourceHash  types.String     `tfsdk:"source_hash"`
+	Resolutions types.Set        `tfsdk:"resolutions"`
+	UseDRM      types.Bool       `tfsdk:"use_drm"`
+	NFTGating   []VideoNFTGating `tfsdk:"nft_gating"`
+	Status      types.String     `tfsdk:"status"`
+	PlaybackURL types.String     `tfsdk:"playback_url"`
+	PlayerURL   types.String     `tfsdk:"player_url"`
+	CreateTime  types.String     `tfsdk:"create_time"`
+	Timeouts    timeouts.Value   `tfsdk:"timeouts"`
+}
+
+type VideoNFTGating struct {
+	ChainID       types.Int64  `tfsdk:"chain_id"`
+	NFTCollection types.String `tfsdk:"nft_collection"`
+}
+
+func (r *videoResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_video"
+}
+
+func (r *videoResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for videos on the Theta Video API. The local source file is uploaded and transcoded, " +
+			"and the resource waits until encoding has finished. The video is uploaded again only when the content of the file changes",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the video",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project whose video API credentials are used",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the video",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The path of the local video file to upload",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"source_hash": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 hash of the uploaded file, a different hash uploads the file as a new video",
+				Computed:            true,
+			},
+			"resolutions": schema.SetAttribute{
+				MarkdownDescription: "The resolutions the video is transcoded to, one or more of: " + strings.Join(videoResolutions, ", ") +
+					". Defaults to the resolutions chosen by the video API",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(videoResolutions...)),
+				},
+			},
+			"use_drm": schema.BoolAttribute{
+				MarkdownDescription: "Whether playback is protected with DRM, required for `nft_gating`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"nft_gating": schema.ListNestedAttribute{
+				MarkdownDescription: "Restricts playback to holders of an NFT of one of the collections",
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"chain_id": schema.Int64Attribute{
+							MarkdownDescription: "The ID of the chain the collection is on, for example 361 for the Theta mainnet",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"nft_collection": schema.StringAttribute{
+							MarkdownDescription: "The contract address of the NFT collection",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(nftCollectionRegexp, "must be a contract address"),
+							},
+						},
+					},
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The encoding state of the video",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"playback_url": schema.StringAttribute{
+				MarkdownDescription: "The HLS URL of the video",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"player_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the video in the Theta video player",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_time": schema.StringAttribute{
+				MarkdownDescription: "The time the video was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *videoResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config VideoState
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(config.NFTGating) > 0 && !config.UseDRM.IsUnknown() && !config.UseDRM.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("use_drm"),
+			"Missing DRM",
+			"NFT gating is enforced through DRM, set use_drm to true when nft_gating is set",
+		)
+	}
+}
+
+// ModifyPlan hashes the source file, so a changed file replaces the video while a moved file only updates the path
+func (r *videoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var source types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("source"), &source)...)
+	if resp.Diagnostics.HasError() || source.IsUnknown() {
+		return
+	}
+
+	hash, err := fileSHA256(source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "Unable to Read Video File", err.Error())
+		return
+	}
+
+	var stateHash types.String
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source_hash"), &stateHash)...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source_hash"), types.StringValue(hash))...)
+
+	// imported videos have no hash to compare against, the next upload only happens once the file changes
+	if stateHash.IsNull() {
+		return
+	}
+	if stateHash.ValueString() != hash {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("source_hash"))
+	}
+}
+
+func (r *videoResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *videoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan VideoState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultVideoCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// the hash is unknown during plan when the path comes from another resource
+	hash, err := fileSHA256(plan.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "Unable to Read Video File", err.Error())
+		return
+	}
+	plan.SourceHash = types.StringValue(hash)
+
+	credentials, err := r.client.GetVideoCredentials(plan.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read video API credentials, got error: %s", err))
+		return
+	}
+
+	uploadID, err := r.client.UploadVideoFile(credentials, plan.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload video file, got error: %s", err))
+		return
+	}
+
+	video, err := r.client.CreateVideo(credentials, convertToVideoRequest(plan, uploadID))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create video, got error: %s", err))
+		return
+	}
+
+	// the video is saved before waiting, so a failed encoding leaves it tainted instead of untracked
+	state := convertToVideoState(video, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	encoded, err := r.client.WaitForVideoEncoded(ctx, credentials, video.ID)
+	if encoded != nil {
+		state = convertToVideoState(encoded, plan)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Video Not Encoded", fmt.Sprintf("Video %s was not encoded: %s", video.ID, err))
+	}
+}
+
+func (r *videoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state VideoState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials, err := r.client.GetVideoCredentials(state.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read video API credentials, got error: %s", err))
+		return
+	}
+
+	video, err := r.client.GetVideo(credentials, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Video Not Found", fmt.Sprintf("Video %s was not found and will be uploaded again.", state.ID.ValueString()))
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read video, got error: %s", err))
+		}
+		return
+	}
+
+	newState := convertToVideoState(video, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+// Update only records a new path of the same file, every other change replaces the video
+func (r *videoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state VideoState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Source = plan.Source
+	state.SourceHash = plan.SourceHash
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *videoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state VideoState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials, err := r.client.GetVideoCredentials(state.ProjectID.ValueString())
+	if err != nil {
+		// the videos of a deleted project are gone with it
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read video API credentials, got error: %s", err))
+		return
+	}
+
+	if err := r.client.DeleteVideo(credentials, state.ID.ValueString()); err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete video, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <project_id>/<video_id>
+func (r *videoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, videoID, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || videoID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id/video_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), videoID)...)
+}
+
+func fileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %v", filePath, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func convertToVideoRequest(plan VideoState, uploadID string) VideoRequest {
+	request := VideoRequest{
+		SourceUploadID: uploadID,
+		PlaybackPolicy: "public",
+		Name:           plan.Name.ValueString(),
+		UseDRM:         plan.UseDRM.ValueBool(),
+	}
+
+	if !plan.Resolutions.IsNull() && !plan.Resolutions.IsUnknown() {
+		request.Resolutions = stringSliceFromSetValue(plan.Resolutions)
+	}
+
+	for _, gating := range plan.NFTGating {
+		request.DRMRules = append(request.DRMRules, VideoDRMRule{
+			ChainID:       gating.ChainID.ValueInt64(),
+			NFTCollection: gating.NFTCollection.ValueString(),
+		})
+	}
+
+	return request
+}
+
+func convertToVideoState(video *Video, prior VideoState) VideoState {
+	state := VideoState{
+		ID:          types.StringValue(video.ID),
+		ProjectID:   prior.ProjectID,
+		Name:        stringValueOrNull(video.Name, prior.Name),
+		Source:      prior.Source,
+		SourceHash:  prior.SourceHash,
+		Resolutions: setValueFromStrings(video.Resolutions, prior.Resolutions),
+		UseDRM:      types.BoolValue(video.UseDRM),
+		Status:      types.StringValue(video.State),
+		PlaybackURL: stringPtrToValue(video.PlaybackURI),
+		PlayerURL:   stringPtrToValue(video.PlayerURI),
+		CreateTime:  types.StringValue(video.CreateTime),
+		Timeouts:    prior.Timeouts,
+	}
+
+	for _, rule := range video.DRMRules {
+		state.NFTGating = append(state.NFTGating, VideoNFTGating{
+			ChainID:       types.Int64Value(rule.ChainID),
+			NFTCollection: types.StringValue(rule.NFTCollection),
+		})
+	}
+
+	return state
+}
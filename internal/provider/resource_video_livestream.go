@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource for Theta Video API livestreams, managed with the video credentials of a project
+type videoLivestreamResource struct {
+	client *Client
+}
+
+var _ resource.ResourceWithImportState = &videoLivestreamResource{}
+
+func VideoLivestreamResource() resource.Resource {
+	return &videoLivestreamResource{}
+}
+
+type VideoLivestreamState struct {
+	ID          types.String             `tfsdk:"id"`
+	ProjectID   types.String             `tfsdk:"project_id"`
+	Name        types.String             `tfsdk:"name"`
+	Resolutions types.Set                `tfsdk:"resolutions"`
+	Fallback    *VideoLivestreamFallback `tfsdk:"fallback"`
+	Status      types.String             `tfsdk:"status"`
+	IngestURL   types.String             `tfsdk:"ingest_url"`
+	StreamKey   types.String             `tfsdk:"stream_key"`
+	PlaybackURL types.String             `tfsdk:"playback_url"`
+	CreateTime  types.String             `tfsdk:"create_time"`
+}
+
+type VideoLivestreamFallback struct {
+	URL            types.String `tfsdk:"url"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+}
+
+func (r *videoLivestreamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_video_livestream"
+}
+
+func (r *videoLivestreamResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for livestreams on the Theta Video API, created with the video API credentials of the project",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the livestream",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project whose video API credentials are used",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the livestream",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"resolutions": schema.SetAttribute{
+				MarkdownDescription: "The resolutions the stream is transcoded to, one or more of: " + strings.Join(videoResolutions, ", ") +
+					". Defaults to the resolutions chosen by the video API",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(videoResolutions...)),
+				},
+			},
+			"fallback": schema.SingleNestedAttribute{
+				MarkdownDescription: "The video or image shown to viewers while nothing is sent to the ingest URL",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "The URL of the fallback video or image",
+						Required:            true,
+					},
+					"timeout_seconds": schema.Int64Attribute{
+						MarkdownDescription: "How long the ingest has to be idle before the fallback is shown",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the livestream, for example `off` or `on`",
+				Computed:            true,
+			},
+			"ingest_url": schema.StringAttribute{
+				MarkdownDescription: "The RTMP URL to send the stream to",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"stream_key": schema.StringAttribute{
+				MarkdownDescription: "The key to send the stream with",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"playback_url": schema.StringAttribute{
+				MarkdownDescription: "The HLS URL viewers watch the stream on",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_time": schema.StringAttribute{
+				MarkdownDescription: "The time the livestream was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *videoLivestreamResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *videoLivestreamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan VideoLivestreamState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials, err := r.client.GetVideoCredentials(plan.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read video API credentials, got error: %s", err))
+		return
+	}
+
+	stream, err := r.client.CreateLivestream(credentials, convertToLivestreamRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create livestream, got error: %s", err))
+		return
+	}
+
+	state := convertToVideoLivestreamState(stream, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *videoLivestreamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state VideoLivestreamState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials, err := r.client.GetVideoCredentials(state.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read video API credentials, got error: %s", err))
+		return
+	}
+
+	stream, err := r.client.GetLivestream(credentials, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Livestream Not Found", fmt.Sprintf("Livestream %s was not found and will be created again.", state.ID.ValueString()))
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read livestream, got error: %s", err))
+		}
+		return
+	}
+
+	newState := convertToVideoLivestreamState(stream, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *videoLivestreamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state VideoLivestreamState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials, err := r.client.GetVideoCredentials(state.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read video API credentials, got error: %s", err))
+		return
+	}
+
+	stream, err := r.client.UpdateLivestream(credentials, state.ID.ValueString(), convertToLivestreamRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update livestream, got error: %s", err))
+		return
+	}
+
+	newState := convertToVideoLivestreamState(stream, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *videoLivestreamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state VideoLivestreamState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials, err := r.client.GetVideoCredentials(state.ProjectID.ValueString())
+	if err != nil {
+		// the streams of a deleted project are gone with it
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read video API credentials, got error: %s", err))
+		return
+	}
+
+	if err := r.client.DeleteLivestream(credentials, state.ID.ValueString()); err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete livestream, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <project_id>/<livestream_id>
+func (r *videoLivestreamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, streamID, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || streamID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id/livestream_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), streamID)...)
+}
+
+func convertToLivestreamRequest(plan VideoLivestreamState) LivestreamRequest {
+	request := LivestreamRequest{
+		Name: plan.Name.ValueString(),
+	}
+
+	if !plan.Resolutions.IsNull() && !plan.Resolutions.IsUnknown() {
+		request.Resolutions = stringSliceFromSetValue(plan.Resolutions)
+	}
+
+	if plan.Fallback != nil {
+		request.Fallback = &LivestreamFallback{
+			URL:            plan.Fallback.URL.ValueString(),
+			TimeoutSeconds: plan.Fallback.TimeoutSeconds.ValueInt64(),
+		}
+	}
+
+	return request
+}
+
+func convertToVideoLivestreamState(stream *Livestream, prior VideoLivestreamState) VideoLivestreamState {
+	state := VideoLivestreamState{
+		ID:          types.StringValue(stream.ID),
+		ProjectID:   prior.ProjectID,
+		Name:        types.StringValue(stream.Name),
+		Resolutions: setValueFromStrings(stream.Resolutions, prior.Resolutions),
+		Status:      types.StringValue(stream.Status),
+		IngestURL:   types.StringValue(stream.StreamServer),
+		StreamKey:   types.StringValue(stream.StreamKey),
+		PlaybackURL: types.StringValue(stream.PlaybackURI),
+		CreateTime:  types.StringValue(stream.CreateTime),
+	}
+
+	if stream.Fallback != nil {
+		priorTimeout := types.Int64Null()
+		if prior.Fallback != nil {
+			priorTimeout = prior.Fallback.TimeoutSeconds
+		}
+		state.Fallback = &VideoLivestreamFallback{
+			URL:            types.StringValue(stream.Fallback.URL),
+			TimeoutSeconds: int64ValueOrNull(stream.Fallback.TimeoutSeconds, priorTimeout),
+		}
+	}
+
+	return state
+}
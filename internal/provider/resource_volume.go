@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource for persistent volumes, mounted into deployments with volume_mounts
+type volumeResource struct {
+	client *Client
+}
+
+var (
+	_ resource.ResourceWithImportState = &volumeResource{}
+	_ resource.ResourceWithModifyPlan  = &volumeResource{}
+)
+
+func VolumeResource() resource.Resource {
+	return &volumeResource{}
+}
+
+type VolumeState struct {
+	ID         types.String `tfsdk:"id"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	Name       types.String `tfsdk:"name"`
+	SizeGB     types.Int64  `tfsdk:"size_gb"`
+	Status     types.String `tfsdk:"status"`
+	CreateTime types.String `tfsdk:"create_time"`
+}
+
+func (r *volumeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "theta_volume"
+}
+
+func (r *volumeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource for persistent volumes. Mount them into deployments with `volume_mounts` to keep data such as " +
+			"model weights or notebooks when deployments are replaced",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the volume",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the volume",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"size_gb": schema.Int64Attribute{
+				MarkdownDescription: "The size of the volume in GB. Volumes are grown in place and can't be shrunk",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the volume",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_time": schema.StringAttribute{
+				MarkdownDescription: "The time the volume was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan rejects shrinking a volume, which would otherwise lose its data
+func (r *volumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planSize, stateSize types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("size_gb"), &planSize)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("size_gb"), &stateSize)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// values coming from other resources are only known at apply time
+	if planSize.IsUnknown() || planSize.IsNull() {
+		return
+	}
+
+	if planSize.ValueInt64() < stateSize.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("size_gb"),
+			"Invalid Volume Size",
+			fmt.Sprintf("Volumes can't be shrunk, size_gb (%d) must not be less than the current size (%d). Replace the volume to start over with a smaller, empty one.",
+				planSize.ValueInt64(), stateSize.ValueInt64()),
+		)
+	}
+}
+
+func (r *volumeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	log.Println("DEBUG: Resource Configure method called")
+
+	if req.ProviderData == nil {
+		log.Println("DEBUG: Provider data is nil")
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *Client")
+		log.Println("DEBUG: Unexpected Resource Configure Type")
+		return
+	}
+
+	r.client = client
+	log.Println("DEBUG: Client configured in resource")
+}
+
+func (r *volumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan VolumeState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volume, err := r.client.CreateVolume(convertToVolumeRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create volume, got error: %s", err))
+		return
+	}
+
+	state := convertToVolumeState(volume, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *volumeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state VolumeState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volume, err := r.client.GetVolume(state.ID.ValueString(), state.ProjectID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.AddWarning("Volume Not Found", fmt.Sprintf("Volume %s was not found and will be created again, empty.", state.ID.ValueString()))
+		} else {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read volume, got error: %s", err))
+		}
+		return
+	}
+
+	newState := convertToVolumeState(volume, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *volumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state VolumeState
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volume, err := r.client.UpdateVolume(state.ID.ValueString(), convertToVolumeRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update volume, got error: %s", err))
+		return
+	}
+
+	newState := convertToVolumeState(volume, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *volumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state VolumeState
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the controller refuses to delete volumes which are still mounted
+	err := r.client.DeleteVolume(state.ID.ValueString(), state.ProjectID.ValueString())
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete volume, got error: %s", err))
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts <project_id>/<volume_id>
+func (r *volumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, volumeID, ok := strings.Cut(req.ID, "/")
+	if !ok || projectID == "" || volumeID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_id/volume_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), volumeID)...)
+}
+
+func convertToVolumeRequest(plan VolumeState) VolumeRequest {
+	return VolumeRequest{
+		Name:      plan.Name.ValueString(),
+		ProjectID: plan.ProjectID.ValueString(),
+		SizeGB:    plan.SizeGB.ValueInt64(),
+	}
+}
+
+func convertToVolumeState(volume *Volume, prior VolumeState) VolumeState {
+	return VolumeState{
+		ID:         types.StringValue(volume.ID),
+		ProjectID:  prior.ProjectID,
+		Name:       types.StringValue(volume.Name),
+		SizeGB:     types.Int64Value(volume.SizeGB),
+		Status:     types.StringValue(volume.Status),
+		CreateTime: types.StringValue(volume.CreateTime),
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"compress/gzip"
 	"compress/zlib"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/andybalholm/brotli"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Utility function to handle compressed responses
@@ -52,7 +56,9 @@ func readCompressedResponse(resp *http.Response) ([]byte, error) {
 	return body, err
 }
 
-func sendRequest(c *Client, method, url string, body []byte) ([]byte, error) {
+// sendRequest sends a JSON request to the API. redactKeys are redacted from the logged bodies in addition
+// to sensitiveKeys, for payloads where an otherwise generic key holds a secret.
+func sendRequest(c *Client, method, url string, body []byte, redactKeys ...string) ([]byte, error) {
 	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
@@ -60,8 +66,13 @@ func sendRequest(c *Client, method, url string, body []byte) ([]byte, error) {
 	req.Header.Set("Content-Type", "application/json")
 	setCommonHeaders(req, c)
 
-	fmt.Printf("DEBUG: Sending %s request to %s\n", method, url)
-	fmt.Printf("DEBUG: Request body: %s\n", string(body))
+	return doRequest(c, req, body, redactKeys...)
+}
+
+// doRequest sends a prepared request, logging it with sensitive values redacted, and returns the response body
+func doRequest(c *Client, req *http.Request, body []byte, redactKeys ...string) ([]byte, error) {
+	fmt.Printf("DEBUG: Sending %s request to %s\n", req.Method, req.URL)
+	fmt.Printf("DEBUG: Request body: %s\n", redactSensitiveJSON(body, redactKeys...))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -72,6 +83,9 @@ func sendRequest(c *Client, method, url string, body []byte) ([]byte, error) {
 	fmt.Printf("DEBUG: Response status: %s\n", resp.Status)
 	for key, values := range resp.Header {
 		for _, value := range values {
+			if sensitiveHeaders[strings.ToLower(key)] {
+				value = redactedValue
+			}
 			fmt.Printf("DEBUG: Header: %s: %s\n", key, value)
 		}
 	}
@@ -81,15 +95,87 @@ func sendRequest(c *Client, method, url string, body []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	fmt.Printf("DEBUG: Response body: %s\n", string(respBody))
+	fmt.Printf("DEBUG: Response body: %s\n", redactSensitiveJSON(respBody, redactKeys...))
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("API request error: %s. Response body: %s: %w", resp.Status, redactSensitiveJSON(respBody, redactKeys...), errNotFound)
+	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request error: %s. Response body: %s", resp.Status, string(respBody))
+		return nil, fmt.Errorf("API request error: %s. Response body: %s", resp.Status, redactSensitiveJSON(respBody, redactKeys...))
 	}
 
 	return respBody, nil
 }
 
+const redactedValue = "<redacted>"
+
+// JSON keys whose values must never end up in logs or error messages, matched case-insensitively
+var sensitiveKeys = map[string]bool{
+	"password":        true,
+	"auth_password":   true,
+	"authpassword":    true,
+	"auth_token":      true,
+	"env_vars":        true,
+	"envvars":         true,
+	"secret_env_vars": true,
+	"tva_secret":      true,
+	"gateway_key":     true,
+	"gateway_secret":  true,
+	"stream_key":      true,
+}
+
+var sensitiveHeaders = map[string]bool{
+	"set-cookie":      true,
+	"authorization":   true,
+	"x-auth-token":    true,
+	"x-tva-sa-secret": true,
+}
+
+// redactSensitiveJSON returns the body for logging with the values of sensitive keys and redactKeys replaced.
+// Bodies which aren't JSON are returned as they are.
+func redactSensitiveJSON(body []byte, redactKeys ...string) string {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return string(body)
+	}
+
+	keys := sensitiveKeys
+	if len(redactKeys) > 0 {
+		keys = make(map[string]bool, len(sensitiveKeys)+len(redactKeys))
+		for key := range sensitiveKeys {
+			keys[key] = true
+		}
+		for _, key := range redactKeys {
+			keys[strings.ToLower(key)] = true
+		}
+	}
+
+	redacted, err := json.Marshal(redactSensitiveValues(data, keys))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func redactSensitiveValues(data interface{}, keys map[string]bool) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if keys[strings.ToLower(key)] && nested != nil {
+				value[key] = redactedValue
+				continue
+			}
+			value[key] = redactSensitiveValues(nested, keys)
+		}
+	case []interface{}:
+		for i, nested := range value {
+			value[i] = redactSensitiveValues(nested, keys)
+		}
+	}
+	return data
+}
+
 func setCommonHeaders(req *http.Request, c *Client) {
 	req.Header.Set("Accept", "*/*")
 	req.Header.Set("Accept-Encoding", "gzip, deflate, br, zstd")
@@ -108,3 +194,72 @@ func setCommonHeaders(req *http.Request, c *Client) {
 	req.Header.Set("X-Auth-Token", c.authToken)
 	req.Header.Set("X-Platform", "web")
 }
+
+// Utility function to build a list attribute, which stays null when the API returns no values
+// and the attribute was null before (omitted from the config) rather than an empty list
+func listValueFromStrings(values []string, prior types.List) types.List {
+	if len(values) == 0 && prior.IsNull() {
+		return types.ListNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, len(values))
+	for i, v := range values {
+		elements[i] = types.StringValue(v)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// Utility function to build a map attribute, following the same null handling as listValueFromStrings
+func mapValueFromStrings(values map[string]string, prior types.Map) types.Map {
+	if len(values) == 0 && prior.IsNull() {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(values))
+	for k, v := range values {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// Utility function to keep an optional string null when the API returns it empty and it was null before
+func stringValueOrNull(value string, prior types.String) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// Utility function to keep an optional number null when the API returns zero and it was null before
+func int64ValueOrNull(value int64, prior types.Int64) types.Int64 {
+	if value == 0 && prior.IsNull() {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
+// Utility function to read the known string elements of a list attribute
+func stringSliceFromValue(list types.List) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	result := make([]string, 0, len(list.Elements()))
+	for _, element := range list.Elements() {
+		if value, ok := element.(types.String); ok {
+			result = append(result, value.ValueString())
+		}
+	}
+	return result
+}
+
+// Utility function to read the known string elements of a map attribute
+func stringMapFromValue(m types.Map) map[string]string {
+	result := make(map[string]string, len(m.Elements()))
+	for key, element := range m.Elements() {
+		if value, ok := element.(types.String); ok {
+			result[key] = value.ValueString()
+		}
+	}
+	return result
+}
@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	// the time zone database is embedded, so time zones validate the same on machines without one
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Tags accepted by the Theta EdgeCloud console for templates and deployments
+var allowedTags = []string{
+	"LLM",
+	"ImageGen",
+	"VideoGen",
+	"AudioGen",
+	"CodeGen",
+	"API",
+}
+
+// Roles a user can have in an organization or a project
+var memberRoles = []string{
+	"admin",
+	"editor",
+	"viewer",
+}
+
+// Output resolutions the Theta Video API transcodes to
+var videoResolutions = []string{
+	"240p",
+	"360p",
+	"480p",
+	"720p",
+	"1080p",
+	"2160p",
+}
+
+// NFT collections are contract addresses on an EVM chain
+var nftCollectionRegexp = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// Volumes are mounted at absolute paths, which must not be the root of the container
+var mountPathRegexp = regexp.MustCompile(`^/[^\s]+$`)
+
+// Project secrets are referenced like environment variables, so their names follow the same rules
+var secretNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Registries are given as a host with an optional port, the way they appear in image references
+var registryHostRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?(:[0-9]+)?$`)
+
+// Loose check for email addresses, the controller does the actual validation
+var emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// Deployment URLs have the format {name}-{id}.{rest_of_theta_domain}, so the name has to be a valid
+// DNS label and leave enough room for the ID within the 63 character label limit
+const maxDeploymentNameLength = 40
+
+var deploymentNameRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// Annotations the provider sets from dedicated attributes
+var reservedAnnotationKeys = map[string]string{
+	tagsAnnotation:     "tags",
+	nicknameAnnotation: "nickname",
+}
+
+// annotationsValidator checks that JSON encoded annotation values can be parsed by the controller
+// and that annotations managed through their own attributes aren't set directly
+type annotationsValidator struct{}
+
+func (v annotationsValidator) Description(ctx context.Context) string {
+	return "annotation values which hold JSON must be valid JSON, and tags and nickname must be set through their own attributes"
+}
+
+func (v annotationsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v annotationsValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for key, element := range req.ConfigValue.Elements() {
+		if attribute, ok := reservedAnnotationKeys[key]; ok {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(key),
+				"Reserved Annotation",
+				fmt.Sprintf("Annotation %q is managed by the provider, use the %s attribute instead", key, attribute),
+			)
+			continue
+		}
+
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		raw := strings.TrimSpace(value.ValueString())
+		if !strings.HasPrefix(raw, "[") && !strings.HasPrefix(raw, "{") {
+			continue
+		}
+
+		if !json.Valid([]byte(raw)) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(key),
+				"Invalid Annotation Value",
+				fmt.Sprintf("Annotation %q must be valid JSON, got: %s", key, value.ValueString()),
+			)
+		}
+	}
+}
+
+// validateSecretEnvVarKeys rejects variables set in both env_vars and secret_env_vars, since the controller
+// keeps them in a single map and only one of the values would be used, and variables also set from a secret_refs entry
+func validateSecretEnvVarKeys(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var envVars, secretEnvVars, secretRefs types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("env_vars"), &envVars)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("secret_env_vars"), &secretEnvVars)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("secret_refs"), &secretRefs)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	envVarElements := envVars.Elements()
+	for key := range secretEnvVars.Elements() {
+		if _, ok := envVarElements[key]; ok {
+			diags.AddAttributeError(
+				path.Root("secret_env_vars").AtMapKey(key),
+				"Duplicate Environment Variable",
+				fmt.Sprintf("Environment variable %q is set in both env_vars and secret_env_vars", key),
+			)
+		}
+	}
+
+	secretEnvVarElements := secretEnvVars.Elements()
+	for key := range secretRefs.Elements() {
+		_, inEnvVars := envVarElements[key]
+		_, inSecretEnvVars := secretEnvVarElements[key]
+		if inEnvVars || inSecretEnvVars {
+			diags.AddAttributeError(
+				path.Root("secret_refs").AtMapKey(key),
+				"Duplicate Environment Variable",
+				fmt.Sprintf("Environment variable %q is set in secret_refs and also in env_vars or secret_env_vars", key),
+			)
+		}
+	}
+
+	return diags
+}
+
+// Allowed values of the five fields of a cron expression: minute, hour, day of month, month and day of week
+var cronFieldRanges = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// cronValidator accepts standard five field cron expressions made of numbers, *, ranges, steps and lists
+type cronValidator struct{}
+
+func (v cronValidator) Description(ctx context.Context) string {
+	return "must be a cron expression with five fields: minute, hour, day of month, month and day of week"
+}
+
+func (v cronValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cronValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateCronExpression(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Cron Expression",
+			fmt.Sprintf("%q %s: %s", req.ConfigValue.ValueString(), v.Description(ctx), err),
+		)
+	}
+}
+
+func validateCronExpression(expression string) error {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFieldRanges) {
+		return fmt.Errorf("expected %d fields, got %d", len(cronFieldRanges), len(fields))
+	}
+
+	for i, field := range fields {
+		fieldRange := cronFieldRanges[i]
+		for _, part := range strings.Split(field, ",") {
+			if err := validateCronPart(part, fieldRange.min, fieldRange.max); err != nil {
+				return fmt.Errorf("invalid %s %q: %v", fieldRange.name, part, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCronPart checks a single element of a list, like *, 5, 1-5, */15 or 0-30/10
+func validateCronPart(part string, min, max int) error {
+	values, step, hasStep := strings.Cut(part, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n < 1 {
+			return fmt.Errorf("step must be a positive number")
+		}
+	}
+
+	if values == "*" {
+		return nil
+	}
+
+	first, last, isRange := strings.Cut(values, "-")
+	start, err := strconv.Atoi(first)
+	if err != nil {
+		return fmt.Errorf("expected *, a number or a range")
+	}
+	end := start
+	if isRange {
+		end, err = strconv.Atoi(last)
+		if err != nil {
+			return fmt.Errorf("expected *, a number or a range")
+		}
+	}
+
+	if start < min || end > max || start > end {
+		return fmt.Errorf("values must be between %d and %d", min, max)
+	}
+	return nil
+}
+
+// timeZoneValidator accepts IANA time zone names such as Europe/Berlin
+type timeZoneValidator struct{}
+
+func (v timeZoneValidator) Description(ctx context.Context) string {
+	return "must be an IANA time zone name such as Europe/Berlin or UTC"
+}
+
+func (v timeZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timeZoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// Local depends on the machine running Terraform, so it's rejected along with unknown names
+	name := req.ConfigValue.ValueString()
+	if _, err := time.LoadLocation(name); err != nil || name == "" || name == "Local" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Zone",
+			fmt.Sprintf("%q %s", name, v.Description(ctx)),
+		)
+	}
+}
@@ -62,7 +62,7 @@ func main() {
 	// 	ProjectID:      project.ID,
 	// 	Description:    "",
 	// 	ContainerImage: "vllm/vllm-openai",
-	// 	Tags:           []string{"LLM", "API"}, // TODO: convert this into enums of allowed tags
+	// 	Tags:           []string{"LLM", "API"},
 	// 	ContainerPort:  "8000",
 	// 	ContainerArgs:  "",
 	// 	EnvVars:        nil,